@@ -35,6 +35,10 @@ type APISpec struct {
 	Basepath *string `json:"basepath,omitempty"`
 	// The OpenAPI definition. Supported only for HTTP APIs.
 	Body *string `json:"body,omitempty"`
+	// Reads the OpenAPI definition from a ConfigMap or Secret key instead of
+	// Body. Changes to the referenced object trigger a reimport of the API.
+	// Supported only for HTTP APIs.
+	BodyFrom *APIBodySource `json:"bodyFrom,omitempty"`
 	// A CORS configuration. Supported only for HTTP APIs. See Configuring CORS
 	// (https://docs.aws.amazon.com/apigateway/latest/developerguide/http-api-cors.html)
 	// for more information.
@@ -57,12 +61,16 @@ type APISpec struct {
 	// Avoid validating models when creating a deployment. Supported only for WebSocket
 	// APIs.
 	DisableSchemaValidation *bool `json:"disableSchemaValidation,omitempty"`
+	// Exports the live API definition into a ConfigMap after each successful
+	// sync. Supported only for HTTP APIs.
+	Export *APIExport `json:"export,omitempty"`
 	// Specifies whether to rollback the API creation when a warning is encountered.
 	// By default, API creation continues if a warning is encountered.
 	FailOnWarnings *bool `json:"failOnWarnings,omitempty"`
 	// The name of the API.
 	Name *string `json:"name,omitempty"`
 	// The API protocol.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	ProtocolType *string `json:"protocolType,omitempty"`
 	// This property is part of quick create. If you don't specify a routeKey, a
 	// default route of $default is created. The $default route acts as a catch-all
@@ -112,6 +120,18 @@ type APIStatus struct {
 	// The API ID.
 	// +kubebuilder:validation:Optional
 	APIID *string `json:"apiID,omitempty"`
+	// The SHA-256 hash of the OpenAPI definition that was last imported.
+	// +kubebuilder:validation:Optional
+	BodyHash *string `json:"bodyHash,omitempty"`
+	// The SHA-256 hash of the API definition that was last exported to the
+	// ConfigMap named in Spec.Export.
+	// +kubebuilder:validation:Optional
+	ExportHash *string `json:"exportHash,omitempty"`
+	// The SHA-256 hash of the export configuration and of the state of the
+	// routes, integrations, authorizers, models and stages of the API when it
+	// was last exported.
+	// +kubebuilder:validation:Optional
+	ExportInputsHash *string `json:"exportInputsHash,omitempty"`
 	// The timestamp when the API was created.
 	// +kubebuilder:validation:Optional
 	CreatedDate *metav1.Time `json:"createdDate,omitempty"`
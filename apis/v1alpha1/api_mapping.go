@@ -31,6 +31,7 @@ type APIMappingSpec struct {
 	APIMappingKey *string                                  `json:"apiMappingKey,omitempty"`
 	APIRef        *ackv1alpha1.AWSResourceReferenceWrapper `json:"apiRef,omitempty"`
 	// The domain name.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	DomainName *string                                  `json:"domainName,omitempty"`
 	DomainRef  *ackv1alpha1.AWSResourceReferenceWrapper `json:"domainRef,omitempty"`
 	// The API stage.
@@ -26,6 +26,7 @@ import (
 type AuthorizerSpec struct {
 
 	// The API identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	APIID  *string                                  `json:"apiID,omitempty"`
 	APIRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"apiRef,omitempty"`
 	// Specifies the required credentials as an IAM role for API Gateway to invoke
@@ -63,6 +64,9 @@ type AuthorizerSpec struct {
 	// only for HTTP APIs. To learn more, see Working with AWS Lambda authorizers
 	// for HTTP APIs (https://docs.aws.amazon.com/apigateway/latest/developerguide/http-api-lambda-authorizer.html)
 	EnableSimpleResponses *bool `json:"enableSimpleResponses,omitempty"`
+	// Resolves AuthorizerURI to the invocation URI of a Function managed by the
+	// ACK Lambda controller. Cannot be used together with AuthorizerURI.
+	FunctionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionRef,omitempty"`
 	// The identity source for which authorization is requested.
 	//
 	// For a REQUEST authorizer, this is optional. The value is a set of one or
@@ -90,6 +94,10 @@ type AuthorizerSpec struct {
 	// Represents the configuration of a JWT authorizer. Required for the JWT authorizer
 	// type. Supported only for HTTP APIs.
 	JWTConfiguration *JWTConfiguration `json:"jwtConfiguration,omitempty"`
+	// Grants API Gateway the permission to invoke the Lambda function of a
+	// REQUEST authorizer, and removes it when the authorizer is deleted or no
+	// longer points at the function.
+	ManageInvokePermission *bool `json:"manageInvokePermission,omitempty"`
 	// The name of the authorizer.
 	// +kubebuilder:validation:Required
 	Name *string `json:"name"`
@@ -111,6 +119,10 @@ type AuthorizerStatus struct {
 	// The authorizer identifier.
 	// +kubebuilder:validation:Optional
 	AuthorizerID *string `json:"authorizerID,omitempty"`
+	// The ARN of the Lambda function on which the controller granted API
+	// Gateway the permission to invoke it.
+	// +kubebuilder:validation:Optional
+	InvokePermissionFunctionARN *string `json:"invokePermissionFunctionARN,omitempty"`
 }
 
 // Authorizer is the Schema for the Authorizers API
@@ -27,6 +27,7 @@ import (
 type DeploymentSpec struct {
 
 	// The API identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	APIID  *string                                  `json:"apiID,omitempty"`
 	APIRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"apiRef,omitempty"`
 	// The description for the deployment resource.
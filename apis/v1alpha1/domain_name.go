@@ -27,6 +27,7 @@ type DomainNameSpec struct {
 
 	// The domain name.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	DomainName *string `json:"domainName"`
 	// The domain name configurations.
 	DomainNameConfigurations []*DomainNameConfiguration `json:"domainNameConfigurations,omitempty"`
@@ -26,6 +26,7 @@ import (
 type IntegrationSpec struct {
 
 	// The API identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	APIID  *string                                  `json:"apiID,omitempty"`
 	APIRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"apiRef,omitempty"`
 	// The ID of the VPC link for a private integration. Supported only for HTTP
@@ -59,6 +60,12 @@ type IntegrationSpec struct {
 	CredentialsARN *string `json:"credentialsARN,omitempty"`
 	// The description of the integration.
 	Description *string `json:"description,omitempty"`
+	// Resolves IntegrationURI to the ARN of an Alias managed by the ACK Lambda
+	// controller. Cannot be used together with IntegrationURI or FunctionRef.
+	FunctionAliasRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionAliasRef,omitempty"`
+	// Resolves IntegrationURI to the ARN of a Function managed by the ACK Lambda
+	// controller. Cannot be used together with IntegrationURI or FunctionAliasRef.
+	FunctionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionRef,omitempty"`
 	// Specifies the integration's HTTP method type.
 	IntegrationMethod *string `json:"integrationMethod,omitempty"`
 	// Supported only for HTTP API AWS_PROXY integrations. Specifies the AWS service
@@ -100,6 +107,10 @@ type IntegrationSpec struct {
 	// To learn more, see DiscoverInstances (https://docs.aws.amazon.com/cloud-map/latest/api/API_DiscoverInstances.html).
 	// For private integrations, all resources must be owned by the same AWS account.
 	IntegrationURI *string `json:"integrationURI,omitempty"`
+	// Grants API Gateway the permission to invoke the Lambda function of an
+	// AWS_PROXY integration, and removes it when the integration is deleted
+	// or no longer points at the function.
+	ManageInvokePermission *bool `json:"manageInvokePermission,omitempty"`
 	// Specifies the pass-through behavior for incoming requests based on the Content-Type
 	// header in the request, and the available mapping templates specified as the
 	// requestTemplates property on the Integration resource. There are three valid
@@ -191,6 +202,10 @@ type IntegrationStatus struct {
 	// Represents the identifier of an integration.
 	// +kubebuilder:validation:Optional
 	IntegrationID *string `json:"integrationID,omitempty"`
+	// The ARN of the Lambda function on which the controller granted API
+	// Gateway the permission to invoke it.
+	// +kubebuilder:validation:Optional
+	InvokePermissionFunctionARN *string `json:"invokePermissionFunctionARN,omitempty"`
 	// The integration response selection expression for the integration. Supported
 	// only for WebSocket APIs. See Integration Response Selection Expressions (https://docs.aws.amazon.com/apigateway/latest/developerguide/apigateway-websocket-api-selection-expressions.html#apigateway-websocket-api-integration-response-selection-expressions).
 	// +kubebuilder:validation:Optional
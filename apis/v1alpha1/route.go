@@ -26,6 +26,7 @@ import (
 type RouteSpec struct {
 
 	// The API identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	APIID *string `json:"apiID,omitempty"`
 	// Specifies whether an API key is required for the route. Supported only for
 	// WebSocket APIs.
@@ -28,6 +28,7 @@ type StageSpec struct {
 	// Settings for logging access in this stage.
 	AccessLogSettings *AccessLogSettings `json:"accessLogSettings,omitempty"`
 	// The API identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	APIID  *string                                  `json:"apiID,omitempty"`
 	APIRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"apiRef,omitempty"`
 	// Specifies whether updates to an API automatically trigger a new deployment.
@@ -47,6 +48,7 @@ type StageSpec struct {
 	RouteSettings map[string]*RouteSettings `json:"routeSettings,omitempty"`
 	// The name of the stage.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	StageName *string `json:"stageName"`
 	// A map that defines the stage variables for a Stage. Variable names can have
 	// alphanumeric and underscore characters, and the values must match [A-Za-z0-9-._~:/?#&=,]+.
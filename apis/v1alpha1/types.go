@@ -18,6 +18,7 @@ package v1alpha1
 import (
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,37 @@ var (
 	_ = ackv1alpha1.AWSAccountID("")
 )
 
+// APIBodySource selects a key of a ConfigMap or a Secret, in the namespace of
+// the API, that holds the OpenAPI definition to import. Exactly one of
+// ConfigMapKeyRef and SecretKeyRef must be set.
+type APIBodySource struct {
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	SecretKeyRef    *corev1.SecretKeySelector    `json:"secretKeyRef,omitempty"`
+}
+
+// APIExport configures the export of the live API definition, as returned by
+// ExportApi, into a ConfigMap in the namespace of the API.
+type APIExport struct {
+	// The name of the ConfigMap that receives the exported definition. The
+	// ConfigMap is created if it does not exist and is owned by the API.
+	// +kubebuilder:validation:Required
+	ConfigMapName *string `json:"configMapName"`
+	// Specifies whether to include API Gateway extensions in the exported
+	// definition. API Gateway extensions are included by default.
+	IncludeExtensions *bool `json:"includeExtensions,omitempty"`
+	// The output type of the exported definition. Valid values are JSON and
+	// YAML. Defaults to JSON.
+	// +kubebuilder:validation:Enum=JSON;YAML
+	OutputType *string `json:"outputType,omitempty"`
+	// The version of the API specification to use. OAS30, for OpenAPI 3.0, is
+	// the only supported value and the default.
+	// +kubebuilder:validation:Enum=OAS30
+	Specification *string `json:"specification,omitempty"`
+	// The name of the API stage to export. If not set, a representation of the
+	// latest API configuration is exported.
+	StageName *string `json:"stageName,omitempty"`
+}
+
 // Represents an API mapping.
 type APIMapping_SDK struct {
 	// The identifier.
@@ -189,7 +221,7 @@ type DomainName_SDK struct {
 }
 
 // Represents an integration response.
-type IntegrationResponse struct {
+type IntegrationResponse_SDK struct {
 	// Specifies how to handle response payload content type conversions. Supported
 	// only for WebSocket APIs.
 	ContentHandlingStrategy *string `json:"contentHandlingStrategy,omitempty"`
@@ -320,7 +352,7 @@ type JWTConfiguration struct {
 
 // Represents a data model for an API. Supported only for WebSocket APIs. See
 // Create Models and Mapping Templates for Request and Response Mappings (https://docs.aws.amazon.com/apigateway/latest/developerguide/models-mappings.html).
-type Model struct {
+type Model_SDK struct {
 	// A string with a length between [0-1024].
 	Description *string `json:"description,omitempty"`
 	// The identifier.
@@ -353,7 +385,7 @@ type ParameterConstraints struct {
 }
 
 // Represents a route response.
-type RouteResponse struct {
+type RouteResponse_SDK struct {
 	// An expression used to extract information at runtime. See Selection Expressions
 	// (https://docs.aws.amazon.com/apigateway/latest/developerguide/apigateway-websocket-api-selection-expressions.html#apigateway-websocket-api-apikey-selection-expressions)
 	// for more information.
@@ -32,6 +32,7 @@ type VPCLinkSpec struct {
 	SecurityGroupIDs []*string `json:"securityGroupIDs,omitempty"`
 	// A list of subnet IDs to include in the VPC link.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	SubnetIDs []*string `json:"subnetIDs"`
 	// A list of tags.
 	Tags map[string]*string `json:"tags,omitempty"`
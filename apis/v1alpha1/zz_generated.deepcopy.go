@@ -21,6 +21,7 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -51,6 +52,71 @@ func (in *API) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIBodySource) DeepCopyInto(out *APIBodySource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIBodySource.
+func (in *APIBodySource) DeepCopy() *APIBodySource {
+	if in == nil {
+		return nil
+	}
+	out := new(APIBodySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExport) DeepCopyInto(out *APIExport) {
+	*out = *in
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.IncludeExtensions != nil {
+		in, out := &in.IncludeExtensions, &out.IncludeExtensions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OutputType != nil {
+		in, out := &in.OutputType, &out.OutputType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Specification != nil {
+		in, out := &in.Specification, &out.Specification
+		*out = new(string)
+		**out = **in
+	}
+	if in.StageName != nil {
+		in, out := &in.StageName, &out.StageName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExport.
+func (in *APIExport) DeepCopy() *APIExport {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIList) DeepCopyInto(out *APIList) {
 	*out = *in
@@ -276,6 +342,11 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(APIBodySource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CORSConfiguration != nil {
 		in, out := &in.CORSConfiguration, &out.CORSConfiguration
 		*out = new(CORS)
@@ -301,6 +372,11 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(APIExport)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailOnWarnings != nil {
 		in, out := &in.FailOnWarnings, &out.FailOnWarnings
 		*out = new(bool)
@@ -398,6 +474,21 @@ func (in *APIStatus) DeepCopyInto(out *APIStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BodyHash != nil {
+		in, out := &in.BodyHash, &out.BodyHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExportHash != nil {
+		in, out := &in.ExportHash, &out.ExportHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExportInputsHash != nil {
+		in, out := &in.ExportInputsHash, &out.ExportInputsHash
+		*out = new(string)
+		**out = **in
+	}
 	if in.CreatedDate != nil {
 		in, out := &in.CreatedDate, &out.CreatedDate
 		*out = (*in).DeepCopy()
@@ -680,6 +771,11 @@ func (in *AuthorizerSpec) DeepCopyInto(out *AuthorizerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IdentitySource != nil {
 		in, out := &in.IdentitySource, &out.IdentitySource
 		*out = make([]*string, len(*in))
@@ -701,6 +797,11 @@ func (in *AuthorizerSpec) DeepCopyInto(out *AuthorizerSpec) {
 		*out = new(JWTConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManageInvokePermission != nil {
+		in, out := &in.ManageInvokePermission, &out.ManageInvokePermission
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -742,6 +843,11 @@ func (in *AuthorizerStatus) DeepCopyInto(out *AuthorizerStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InvokePermissionFunctionARN != nil {
+		in, out := &in.InvokePermissionFunctionARN, &out.InvokePermissionFunctionARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizerStatus.
@@ -1397,6 +1503,183 @@ func (in *IntegrationList) DeepCopyObject() runtime.Object {
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationResponse) DeepCopyInto(out *IntegrationResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponse.
+func (in *IntegrationResponse) DeepCopy() *IntegrationResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationResponseList) DeepCopyInto(out *IntegrationResponseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IntegrationResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponseList.
+func (in *IntegrationResponseList) DeepCopy() *IntegrationResponseList {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationResponseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IntegrationResponseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationResponseSpec) DeepCopyInto(out *IntegrationResponseSpec) {
+	*out = *in
+	if in.APIID != nil {
+		in, out := &in.APIID, &out.APIID
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIRef != nil {
+		in, out := &in.APIRef, &out.APIRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentHandlingStrategy != nil {
+		in, out := &in.ContentHandlingStrategy, &out.ContentHandlingStrategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.IntegrationID != nil {
+		in, out := &in.IntegrationID, &out.IntegrationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.IntegrationRef != nil {
+		in, out := &in.IntegrationRef, &out.IntegrationRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrationResponseKey != nil {
+		in, out := &in.IntegrationResponseKey, &out.IntegrationResponseKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResponseParameters != nil {
+		in, out := &in.ResponseParameters, &out.ResponseParameters
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ResponseTemplates != nil {
+		in, out := &in.ResponseTemplates, &out.ResponseTemplates
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TemplateSelectionExpression != nil {
+		in, out := &in.TemplateSelectionExpression, &out.TemplateSelectionExpression
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponseSpec.
+func (in *IntegrationResponseSpec) DeepCopy() *IntegrationResponseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationResponseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationResponseStatus) DeepCopyInto(out *IntegrationResponseStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.IntegrationResponseID != nil {
+		in, out := &in.IntegrationResponseID, &out.IntegrationResponseID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponseStatus.
+func (in *IntegrationResponseStatus) DeepCopy() *IntegrationResponseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationResponseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationResponse_SDK) DeepCopyInto(out *IntegrationResponse_SDK) {
 	*out = *in
 	if in.ContentHandlingStrategy != nil {
 		in, out := &in.ContentHandlingStrategy, &out.ContentHandlingStrategy
@@ -1452,12 +1735,12 @@ func (in *IntegrationResponse) DeepCopyInto(out *IntegrationResponse) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponse.
-func (in *IntegrationResponse) DeepCopy() *IntegrationResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationResponse_SDK.
+func (in *IntegrationResponse_SDK) DeepCopy() *IntegrationResponse_SDK {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationResponse)
+	out := new(IntegrationResponse_SDK)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1505,6 +1788,16 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FunctionAliasRef != nil {
+		in, out := &in.FunctionAliasRef, &out.FunctionAliasRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IntegrationMethod != nil {
 		in, out := &in.IntegrationMethod, &out.IntegrationMethod
 		*out = new(string)
@@ -1525,6 +1818,11 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ManageInvokePermission != nil {
+		in, out := &in.ManageInvokePermission, &out.ManageInvokePermission
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PassthroughBehavior != nil {
 		in, out := &in.PassthroughBehavior, &out.PassthroughBehavior
 		*out = new(string)
@@ -1650,6 +1948,11 @@ func (in *IntegrationStatus) DeepCopyInto(out *IntegrationStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InvokePermissionFunctionARN != nil {
+		in, out := &in.InvokePermissionFunctionARN, &out.InvokePermissionFunctionARN
+		*out = new(string)
+		**out = **in
+	}
 	if in.IntegrationResponseSelectionExpression != nil {
 		in, out := &in.IntegrationResponseSelectionExpression, &out.IntegrationResponseSelectionExpression
 		*out = new(string)
@@ -1860,14 +2163,83 @@ func (in *JWTConfiguration) DeepCopy() *JWTConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
-		*out = new(string)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
+func (in *Model) DeepCopy() *Model {
+	if in == nil {
+		return nil
 	}
-	if in.ModelID != nil {
-		in, out := &in.ModelID, &out.ModelID
-		*out = new(string)
+	out := new(Model)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Model) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelList) DeepCopyInto(out *ModelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Model, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
+func (in *ModelList) DeepCopy() *ModelList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	if in.APIID != nil {
+		in, out := &in.APIID, &out.APIID
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIRef != nil {
+		in, out := &in.APIRef, &out.APIRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
 		**out = **in
 	}
 	if in.Name != nil {
@@ -1882,12 +2254,83 @@ func (in *Model) DeepCopyInto(out *Model) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
-func (in *Model) DeepCopy() *Model {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Model)
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ModelID != nil {
+		in, out := &in.ModelID, &out.ModelID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Model_SDK) DeepCopyInto(out *Model_SDK) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.ModelID != nil {
+		in, out := &in.ModelID, &out.ModelID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model_SDK.
+func (in *Model_SDK) DeepCopy() *Model_SDK {
+	if in == nil {
+		return nil
+	}
+	out := new(Model_SDK)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2034,6 +2477,210 @@ func (in *RouteList) DeepCopyObject() runtime.Object {
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteResponse) DeepCopyInto(out *RouteResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponse.
+func (in *RouteResponse) DeepCopy() *RouteResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteResponseList) DeepCopyInto(out *RouteResponseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponseList.
+func (in *RouteResponseList) DeepCopy() *RouteResponseList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteResponseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteResponseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteResponseSpec) DeepCopyInto(out *RouteResponseSpec) {
+	*out = *in
+	if in.APIID != nil {
+		in, out := &in.APIID, &out.APIID
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIRef != nil {
+		in, out := &in.APIRef, &out.APIRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelSelectionExpression != nil {
+		in, out := &in.ModelSelectionExpression, &out.ModelSelectionExpression
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResponseModelRefs != nil {
+		in, out := &in.ResponseModelRefs, &out.ResponseModelRefs
+		*out = make(map[string]*corev1alpha1.AWSResourceReferenceWrapper, len(*in))
+		for key, val := range *in {
+			var outVal *corev1alpha1.AWSResourceReferenceWrapper
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ResponseModels != nil {
+		in, out := &in.ResponseModels, &out.ResponseModels
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ResponseParameters != nil {
+		in, out := &in.ResponseParameters, &out.ResponseParameters
+		*out = make(map[string]*ParameterConstraints, len(*in))
+		for key, val := range *in {
+			var outVal *ParameterConstraints
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(ParameterConstraints)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.RouteID != nil {
+		in, out := &in.RouteID, &out.RouteID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RouteRef != nil {
+		in, out := &in.RouteRef, &out.RouteRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteResponseKey != nil {
+		in, out := &in.RouteResponseKey, &out.RouteResponseKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponseSpec.
+func (in *RouteResponseSpec) DeepCopy() *RouteResponseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteResponseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteResponseStatus) DeepCopyInto(out *RouteResponseStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ResolvedResponseModels != nil {
+		in, out := &in.ResolvedResponseModels, &out.ResolvedResponseModels
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.RouteResponseID != nil {
+		in, out := &in.RouteResponseID, &out.RouteResponseID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponseStatus.
+func (in *RouteResponseStatus) DeepCopy() *RouteResponseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteResponseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteResponse_SDK) DeepCopyInto(out *RouteResponse_SDK) {
 	*out = *in
 	if in.ModelSelectionExpression != nil {
 		in, out := &in.ModelSelectionExpression, &out.ModelSelectionExpression
@@ -2084,12 +2731,12 @@ func (in *RouteResponse) DeepCopyInto(out *RouteResponse) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponse.
-func (in *RouteResponse) DeepCopy() *RouteResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteResponse_SDK.
+func (in *RouteResponse_SDK) DeepCopy() *RouteResponse_SDK {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteResponse)
+	out := new(RouteResponse_SDK)
 	in.DeepCopyInto(out)
 	return out
 }
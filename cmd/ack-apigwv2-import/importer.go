@@ -0,0 +1,457 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// Client is the subset of the API Gateway v2 client used to read an API and
+// the resources attached to it. It is satisfied by *apigatewayv2.Client.
+type Client interface {
+	GetApi(context.Context, *svcsdk.GetApiInput, ...func(*svcsdk.Options)) (*svcsdk.GetApiOutput, error)
+	GetAuthorizers(context.Context, *svcsdk.GetAuthorizersInput, ...func(*svcsdk.Options)) (*svcsdk.GetAuthorizersOutput, error)
+	GetIntegrations(context.Context, *svcsdk.GetIntegrationsInput, ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationsOutput, error)
+	GetRoutes(context.Context, *svcsdk.GetRoutesInput, ...func(*svcsdk.Options)) (*svcsdk.GetRoutesOutput, error)
+	GetDeployments(context.Context, *svcsdk.GetDeploymentsInput, ...func(*svcsdk.Options)) (*svcsdk.GetDeploymentsOutput, error)
+	GetStages(context.Context, *svcsdk.GetStagesInput, ...func(*svcsdk.Options)) (*svcsdk.GetStagesOutput, error)
+	GetDomainNames(context.Context, *svcsdk.GetDomainNamesInput, ...func(*svcsdk.Options)) (*svcsdk.GetDomainNamesOutput, error)
+	GetApiMappings(context.Context, *svcsdk.GetApiMappingsInput, ...func(*svcsdk.Options)) (*svcsdk.GetApiMappingsOutput, error)
+	GetVpcLink(context.Context, *svcsdk.GetVpcLinkInput, ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinkOutput, error)
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// importer builds the custom resources that adopt an existing API and the
+// resources attached to it. The resources reference each other by the names
+// of the custom resources, so that the set can be applied as is.
+type importer struct {
+	client    Client
+	namespace string
+
+	// names holds the names given to the custom resources of each kind, to
+	// keep them unique.
+	names map[string]map[string]bool
+	// refs maps the IDs of the imported resources to the names of their
+	// custom resources, by kind.
+	refs map[string]map[string]string
+}
+
+func newImporter(c Client, namespace string) *importer {
+	return &importer{
+		client:    c,
+		namespace: namespace,
+		names:     map[string]map[string]bool{},
+		refs:      map[string]map[string]string{},
+	}
+}
+
+// importAPI reads the API with the given ID and returns the custom resources
+// for the API, its VPC links, authorizers, integrations, routes, deployments
+// and stages, and the custom domain names mapped to it, in the order they
+// depend on each other. Routes and integrations managed by API Gateway, such
+// as the ones of a quick create, and automatic deployments are skipped.
+func (im *importer) importAPI(ctx context.Context, apiID string) ([]client.Object, error) {
+	api, err := im.client.GetApi(ctx, &svcsdk.GetApiInput{ApiId: aws.String(apiID)})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read API %s: %w", apiID, err)
+	}
+	apiCR := &svcapitypes.API{ObjectMeta: im.objectMeta("API", aws.ToString(api.Name), apiID, map[string]string{
+		"apiID": apiID,
+	})}
+	if err := convert(api, &apiCR.Spec); err != nil {
+		return nil, err
+	}
+	apiCR.Spec.Tags = userTags(api.Tags)
+	apiRef := ref(apiCR.Name)
+	// the custom resources of the resources attached to the API are prefixed
+	// with the name of the API, as several APIs may share a namespace.
+	prefix := apiCR.Name + "-"
+
+	authorizers, err := paginate(func(token *string) ([]svcsdktypes.Authorizer, *string, error) {
+		resp, err := im.client.GetAuthorizers(ctx, &svcsdk.GetAuthorizersInput{ApiId: &apiID, NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the authorizers of API %s: %w", apiID, err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var authorizerCRs []client.Object
+	for _, item := range authorizers {
+		cr := &svcapitypes.Authorizer{ObjectMeta: im.objectMeta(
+			"Authorizer", prefix+aws.ToString(item.Name), aws.ToString(item.AuthorizerId),
+			map[string]string{"apiID": apiID, "authorizerID": aws.ToString(item.AuthorizerId)},
+		)}
+		if err := convert(item, &cr.Spec); err != nil {
+			return nil, err
+		}
+		cr.Spec.APIRef = apiRef
+		authorizerCRs = append(authorizerCRs, cr)
+	}
+
+	integrations, err := paginate(func(token *string) ([]svcsdktypes.Integration, *string, error) {
+		resp, err := im.client.GetIntegrations(ctx, &svcsdk.GetIntegrationsInput{ApiId: &apiID, NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the integrations of API %s: %w", apiID, err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var vpcLinkCRs, integrationCRs []client.Object
+	for _, item := range integrations {
+		if aws.ToBool(item.ApiGatewayManaged) {
+			continue
+		}
+		cr := &svcapitypes.Integration{ObjectMeta: im.objectMeta(
+			"Integration", prefix+aws.ToString(item.IntegrationId), aws.ToString(item.IntegrationId),
+			map[string]string{"apiID": apiID, "integrationID": aws.ToString(item.IntegrationId)},
+		)}
+		if err := convert(item, &cr.Spec); err != nil {
+			return nil, err
+		}
+		cr.Spec.APIRef = apiRef
+		if item.ConnectionType == svcsdktypes.ConnectionTypeVpcLink && item.ConnectionId != nil {
+			name, vpcLink, err := im.importVPCLink(ctx, *item.ConnectionId)
+			if err != nil {
+				return nil, err
+			}
+			if vpcLink != nil {
+				vpcLinkCRs = append(vpcLinkCRs, vpcLink)
+			}
+			cr.Spec.ConnectionID = nil
+			cr.Spec.ConnectionRef = ref(name)
+		}
+		integrationCRs = append(integrationCRs, cr)
+	}
+
+	routes, err := paginate(func(token *string) ([]svcsdktypes.Route, *string, error) {
+		resp, err := im.client.GetRoutes(ctx, &svcsdk.GetRoutesInput{ApiId: &apiID, NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the routes of API %s: %w", apiID, err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var routeCRs []client.Object
+	for _, item := range routes {
+		if aws.ToBool(item.ApiGatewayManaged) {
+			continue
+		}
+		cr := &svcapitypes.Route{ObjectMeta: im.objectMeta(
+			"Route", prefix+aws.ToString(item.RouteKey), aws.ToString(item.RouteId),
+			map[string]string{"apiID": apiID, "routeID": aws.ToString(item.RouteId)},
+		)}
+		if err := convert(item, &cr.Spec); err != nil {
+			return nil, err
+		}
+		cr.Spec.APIRef = apiRef
+		if name, ok := im.refs["Authorizer"][aws.ToString(item.AuthorizerId)]; ok {
+			cr.Spec.AuthorizerID = nil
+			cr.Spec.AuthorizerRef = ref(name)
+		}
+		integrationID, ok := strings.CutPrefix(aws.ToString(item.Target), "integrations/")
+		if name, known := im.refs["Integration"][integrationID]; ok && known {
+			cr.Spec.Target = nil
+			cr.Spec.TargetRef = ref(name)
+		}
+		routeCRs = append(routeCRs, cr)
+	}
+
+	deployments, err := paginate(func(token *string) ([]svcsdktypes.Deployment, *string, error) {
+		resp, err := im.client.GetDeployments(ctx, &svcsdk.GetDeploymentsInput{ApiId: &apiID, NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the deployments of API %s: %w", apiID, err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var deploymentCRs []client.Object
+	for _, item := range deployments {
+		if aws.ToBool(item.AutoDeployed) {
+			continue
+		}
+		cr := &svcapitypes.Deployment{ObjectMeta: im.objectMeta(
+			"Deployment", prefix+aws.ToString(item.DeploymentId), aws.ToString(item.DeploymentId),
+			map[string]string{"apiID": apiID, "deploymentID": aws.ToString(item.DeploymentId)},
+		)}
+		cr.Spec.Description = item.Description
+		cr.Spec.APIRef = apiRef
+		deploymentCRs = append(deploymentCRs, cr)
+	}
+
+	stages, err := paginate(func(token *string) ([]svcsdktypes.Stage, *string, error) {
+		resp, err := im.client.GetStages(ctx, &svcsdk.GetStagesInput{ApiId: &apiID, NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the stages of API %s: %w", apiID, err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var stageCRs []client.Object
+	for _, item := range stages {
+		cr := &svcapitypes.Stage{ObjectMeta: im.objectMeta(
+			"Stage", prefix+aws.ToString(item.StageName), aws.ToString(item.StageName),
+			map[string]string{"apiID": apiID, "stageName": aws.ToString(item.StageName)},
+		)}
+		if err := convert(item, &cr.Spec); err != nil {
+			return nil, err
+		}
+		cr.Spec.APIRef = apiRef
+		cr.Spec.Tags = userTags(item.Tags)
+		// the deployments of an auto-deployed stage are made by API Gateway
+		cr.Spec.DeploymentID = nil
+		if !aws.ToBool(item.AutoDeploy) {
+			if name, ok := im.refs["Deployment"][aws.ToString(item.DeploymentId)]; ok {
+				cr.Spec.DeploymentRef = ref(name)
+			} else {
+				cr.Spec.DeploymentID = item.DeploymentId
+			}
+		}
+		stageCRs = append(stageCRs, cr)
+	}
+
+	domainCRs, err := im.importDomainMappings(ctx, apiID, apiCR.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := append(vpcLinkCRs, apiCR)
+	for _, crs := range [][]client.Object{
+		authorizerCRs, integrationCRs, routeCRs, deploymentCRs, stageCRs, domainCRs,
+	} {
+		objs = append(objs, crs...)
+	}
+	return objs, nil
+}
+
+// importVPCLink returns the name of the custom resource of the VPC link with
+// the given ID, along with the custom resource itself the first time the VPC
+// link is seen.
+func (im *importer) importVPCLink(ctx context.Context, vpcLinkID string) (string, client.Object, error) {
+	if name, ok := im.refs["VPCLink"][vpcLinkID]; ok {
+		return name, nil, nil
+	}
+	resp, err := im.client.GetVpcLink(ctx, &svcsdk.GetVpcLinkInput{VpcLinkId: &vpcLinkID})
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot read VPC link %s: %w", vpcLinkID, err)
+	}
+	cr := &svcapitypes.VPCLink{ObjectMeta: im.objectMeta(
+		"VPCLink", aws.ToString(resp.Name), vpcLinkID, map[string]string{"vpcLinkID": vpcLinkID},
+	)}
+	if err := convert(resp, &cr.Spec); err != nil {
+		return "", nil, err
+	}
+	cr.Spec.Tags = userTags(resp.Tags)
+	return cr.Name, cr, nil
+}
+
+// importDomainMappings returns the custom resources of the custom domain
+// names that have API mappings to the API, followed by the mappings.
+func (im *importer) importDomainMappings(ctx context.Context, apiID, apiName string) ([]client.Object, error) {
+	domains, err := paginate(func(token *string) ([]svcsdktypes.DomainName, *string, error) {
+		resp, err := im.client.GetDomainNames(ctx, &svcsdk.GetDomainNamesInput{NextToken: token})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list the custom domain names: %w", err)
+		}
+		return resp.Items, resp.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var domainCRs, mappingCRs []client.Object
+	for _, domain := range domains {
+		domainName := aws.ToString(domain.DomainName)
+		mappings, err := paginate(func(token *string) ([]svcsdktypes.ApiMapping, *string, error) {
+			resp, err := im.client.GetApiMappings(ctx, &svcsdk.GetApiMappingsInput{
+				DomainName: &domainName,
+				NextToken:  token,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot list the API mappings of %s: %w", domainName, err)
+			}
+			return resp.Items, resp.NextToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		var domainRef *ackv1alpha1.AWSResourceReferenceWrapper
+		for _, mapping := range mappings {
+			if aws.ToString(mapping.ApiId) != apiID {
+				continue
+			}
+			if domainRef == nil {
+				cr := &svcapitypes.DomainName{ObjectMeta: im.objectMeta(
+					"DomainName", domainName, domainName, map[string]string{"domainName": domainName},
+				)}
+				if err := convert(domain, &cr.Spec); err != nil {
+					return nil, err
+				}
+				cr.Spec.Tags = userTags(domain.Tags)
+				domainCRs = append(domainCRs, cr)
+				domainRef = ref(cr.Name)
+			}
+			mappingID := aws.ToString(mapping.ApiMappingId)
+			cr := &svcapitypes.APIMapping{ObjectMeta: im.objectMeta(
+				"APIMapping", apiName+"-"+domainName, mappingID,
+				map[string]string{"domainName": domainName, "apiMappingID": mappingID},
+			)}
+			cr.Spec.APIRef = ref(apiName)
+			cr.Spec.DomainRef = domainRef
+			cr.Spec.APIMappingKey = mapping.ApiMappingKey
+			cr.Spec.Stage = mapping.Stage
+			mappingCRs = append(mappingCRs, cr)
+		}
+	}
+	return append(domainCRs, mappingCRs...), nil
+}
+
+// objectMeta returns the metadata of a custom resource that adopts the AWS
+// resource with the given ID. The name of the custom resource is derived from
+// a name of the AWS resource, or its ID if that does not make for a valid
+// name, and is recorded so that other custom resources can refer to it.
+func (im *importer) objectMeta(kind, name, id string, fields map[string]string) metav1.ObjectMeta {
+	base := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if base == "" {
+		base = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(id), "-"), "-")
+	}
+	if len(base) > 56 {
+		base = strings.TrimRight(base[:56], "-")
+	}
+	if im.names[kind] == nil {
+		im.names[kind] = map[string]bool{}
+		im.refs[kind] = map[string]string{}
+	}
+	unique := base
+	for i := 2; im.names[kind][unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", base, i)
+	}
+	im.names[kind][unique] = true
+	im.refs[kind][id] = unique
+
+	adoptionFields, _ := json.Marshal(fields)
+	return metav1.ObjectMeta{
+		Name:      unique,
+		Namespace: im.namespace,
+		Annotations: map[string]string{
+			ackv1alpha1.AnnotationAdoptionPolicy: "adopt",
+			ackv1alpha1.AnnotationAdoptionFields: string(adoptionFields),
+		},
+	}
+}
+
+// convert copies the fields of an API Gateway output shape to the spec of a
+// custom resource. The field names of the output shapes and the JSON names
+// of the spec fields only differ in case, which encoding/json ignores when
+// decoding; output fields without a spec counterpart are dropped. Unset enum
+// fields, which are empty strings in the output shapes, are left unset.
+func convert(out interface{}, spec interface{}) error {
+	b, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	var fields interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	dropEmptyStrings(fields)
+	if b, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, spec)
+}
+
+// dropEmptyStrings removes the fields with an empty string value from the
+// decoded JSON objects in v.
+func dropEmptyStrings(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if field == "" {
+				delete(v, k)
+				continue
+			}
+			dropEmptyStrings(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			dropEmptyStrings(item)
+		}
+	}
+}
+
+// userTags returns the tags that can be managed through the tags of a
+// custom resource, leaving out the "aws:" tags set by AWS services such as
+// CloudFormation.
+func userTags(tags map[string]string) map[string]*string {
+	var result map[string]*string
+	for k, v := range tags {
+		if strings.HasPrefix(k, "aws:") {
+			continue
+		}
+		if result == nil {
+			result = map[string]*string{}
+		}
+		result[k] = aws.String(v)
+	}
+	return result
+}
+
+// ref returns a reference to the custom resource with the given name in the
+// same namespace.
+func ref(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	return &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+	}
+}
+
+// paginate calls list with the token of the previous page until the last
+// page and returns the items of all pages.
+func paginate[T any](list func(token *string) ([]T, *string, error)) ([]T, error) {
+	var items []T
+	var token *string
+	for {
+		page, next, err := list(token)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if next == nil {
+			return items, nil
+		}
+		token = next
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// fakeClient serves a single HTTP API. The routes are split over two pages.
+type fakeClient struct{}
+
+func (fakeClient) GetApi(_ context.Context, in *svcsdk.GetApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetApiOutput, error) {
+	return &svcsdk.GetApiOutput{
+		ApiId:        in.ApiId,
+		ApiEndpoint:  aws.String("https://a1.execute-api.us-west-2.amazonaws.com"),
+		Name:         aws.String("Pets API"),
+		ProtocolType: svcsdktypes.ProtocolTypeHttp,
+		CorsConfiguration: &svcsdktypes.Cors{
+			AllowOrigins: []string{"https://example.com"},
+		},
+		Tags: map[string]string{
+			"team":                          "pets",
+			"aws:cloudformation:stack-name": "pets",
+		},
+	}, nil
+}
+
+func (fakeClient) GetAuthorizers(context.Context, *svcsdk.GetAuthorizersInput, ...func(*svcsdk.Options)) (*svcsdk.GetAuthorizersOutput, error) {
+	return &svcsdk.GetAuthorizersOutput{Items: []svcsdktypes.Authorizer{{
+		AuthorizerId:   aws.String("au1"),
+		AuthorizerType: svcsdktypes.AuthorizerTypeJwt,
+		Name:           aws.String("cognito"),
+		IdentitySource: []string{"$request.header.Authorization"},
+		JwtConfiguration: &svcsdktypes.JWTConfiguration{
+			Audience: []string{"pets"},
+			Issuer:   aws.String("https://issuer.example.com"),
+		},
+	}}}, nil
+}
+
+func (fakeClient) GetIntegrations(context.Context, *svcsdk.GetIntegrationsInput, ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationsOutput, error) {
+	return &svcsdk.GetIntegrationsOutput{Items: []svcsdktypes.Integration{
+		{
+			IntegrationId:        aws.String("in1"),
+			IntegrationType:      svcsdktypes.IntegrationTypeHttpProxy,
+			IntegrationMethod:    aws.String("ANY"),
+			IntegrationUri:       aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/pets/1/2"),
+			ConnectionType:       svcsdktypes.ConnectionTypeVpcLink,
+			ConnectionId:         aws.String("vl1"),
+			PayloadFormatVersion: aws.String("1.0"),
+			TimeoutInMillis:      aws.Int32(5000),
+		},
+		{
+			IntegrationId:     aws.String("in2"),
+			IntegrationType:   svcsdktypes.IntegrationTypeHttpProxy,
+			ApiGatewayManaged: aws.Bool(true),
+		},
+	}}, nil
+}
+
+func (fakeClient) GetRoutes(_ context.Context, in *svcsdk.GetRoutesInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetRoutesOutput, error) {
+	if in.NextToken == nil {
+		return &svcsdk.GetRoutesOutput{
+			Items: []svcsdktypes.Route{{
+				RouteId:           aws.String("r1"),
+				RouteKey:          aws.String("GET /pets"),
+				Target:            aws.String("integrations/in1"),
+				AuthorizationType: svcsdktypes.AuthorizationTypeJwt,
+				AuthorizerId:      aws.String("au1"),
+			}},
+			NextToken: aws.String("page2"),
+		}, nil
+	}
+	return &svcsdk.GetRoutesOutput{Items: []svcsdktypes.Route{
+		{RouteId: aws.String("r2"), RouteKey: aws.String("GET /pets/"), Target: aws.String("integrations/in1")},
+		{RouteId: aws.String("r3"), RouteKey: aws.String("$default"), ApiGatewayManaged: aws.Bool(true)},
+	}}, nil
+}
+
+func (fakeClient) GetDeployments(context.Context, *svcsdk.GetDeploymentsInput, ...func(*svcsdk.Options)) (*svcsdk.GetDeploymentsOutput, error) {
+	return &svcsdk.GetDeploymentsOutput{Items: []svcsdktypes.Deployment{
+		{DeploymentId: aws.String("d1"), Description: aws.String("release")},
+		{DeploymentId: aws.String("d2"), AutoDeployed: aws.Bool(true)},
+	}}, nil
+}
+
+func (fakeClient) GetStages(context.Context, *svcsdk.GetStagesInput, ...func(*svcsdk.Options)) (*svcsdk.GetStagesOutput, error) {
+	return &svcsdk.GetStagesOutput{Items: []svcsdktypes.Stage{
+		{StageName: aws.String("prod"), DeploymentId: aws.String("d1")},
+		{StageName: aws.String("$default"), DeploymentId: aws.String("d2"), AutoDeploy: aws.Bool(true)},
+	}}, nil
+}
+
+func (fakeClient) GetDomainNames(context.Context, *svcsdk.GetDomainNamesInput, ...func(*svcsdk.Options)) (*svcsdk.GetDomainNamesOutput, error) {
+	return &svcsdk.GetDomainNamesOutput{Items: []svcsdktypes.DomainName{
+		{
+			DomainName: aws.String("pets.example.com"),
+			DomainNameConfigurations: []svcsdktypes.DomainNameConfiguration{{
+				CertificateArn: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/1"),
+				EndpointType:   svcsdktypes.EndpointTypeRegional,
+				HostedZoneId:   aws.String("Z1"),
+			}},
+		},
+		{DomainName: aws.String("other.example.com")},
+	}}, nil
+}
+
+func (fakeClient) GetApiMappings(_ context.Context, in *svcsdk.GetApiMappingsInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetApiMappingsOutput, error) {
+	if *in.DomainName != "pets.example.com" {
+		return &svcsdk.GetApiMappingsOutput{Items: []svcsdktypes.ApiMapping{
+			{ApiMappingId: aws.String("m9"), ApiId: aws.String("other"), Stage: aws.String("prod")},
+		}}, nil
+	}
+	return &svcsdk.GetApiMappingsOutput{Items: []svcsdktypes.ApiMapping{
+		{ApiMappingId: aws.String("m1"), ApiId: aws.String("a1"), Stage: aws.String("prod"), ApiMappingKey: aws.String("v1")},
+	}}, nil
+}
+
+func (fakeClient) GetVpcLink(_ context.Context, in *svcsdk.GetVpcLinkInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinkOutput, error) {
+	return &svcsdk.GetVpcLinkOutput{
+		VpcLinkId: in.VpcLinkId,
+		Name:      aws.String("pets-vpc"),
+		SubnetIds: []string{"subnet-1", "subnet-2"},
+	}, nil
+}
+
+func refName(ref *ackv1alpha1.AWSResourceReferenceWrapper) string {
+	if ref == nil || ref.From == nil {
+		return ""
+	}
+	return aws.ToString(ref.From.Name)
+}
+
+func TestImportAPI(t *testing.T) {
+	objs, err := newImporter(fakeClient{}, "pets").importAPI(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("importAPI() error = %v", err)
+	}
+
+	var got []string
+	byName := map[string]client.Object{}
+	for _, obj := range objs {
+		got = append(got, obj.GetName())
+		byName[obj.GetName()] = obj
+		if obj.GetNamespace() != "pets" {
+			t.Errorf("%s namespace = %q, want pets", obj.GetName(), obj.GetNamespace())
+		}
+		if obj.GetAnnotations()[ackv1alpha1.AnnotationAdoptionPolicy] != "adopt" {
+			t.Errorf("%s is not adopted", obj.GetName())
+		}
+	}
+	want := []string{
+		"pets-vpc", "pets-api", "pets-api-cognito", "pets-api-in1", "pets-api-get-pets", "pets-api-get-pets-2",
+		"pets-api-d1", "pets-api-prod", "pets-api-default", "pets-example-com", "pets-api-pets-example-com",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("importAPI() names = %v, want %v", got, want)
+	}
+
+	api := byName["pets-api"].(*svcapitypes.API)
+	if got := api.Annotations[ackv1alpha1.AnnotationAdoptionFields]; got != `{"apiID":"a1"}` {
+		t.Errorf("API adoption fields = %s", got)
+	}
+	if aws.ToString(api.Spec.Name) != "Pets API" || len(api.Spec.CORSConfiguration.AllowOrigins) != 1 {
+		t.Errorf("API spec = %+v, want the API converted", api.Spec)
+	}
+	if len(api.Spec.Tags) != 1 || aws.ToString(api.Spec.Tags["team"]) != "pets" {
+		t.Errorf("API tags = %v, want the aws: tags left out", api.Spec.Tags)
+	}
+
+	integration := byName["pets-api-in1"].(*svcapitypes.Integration)
+	if refName(integration.Spec.ConnectionRef) != "pets-vpc" || integration.Spec.ConnectionID != nil {
+		t.Errorf("integration connection = %v/%v, want a connectionRef", integration.Spec.ConnectionID, integration.Spec.ConnectionRef)
+	}
+	if aws.ToInt64(integration.Spec.TimeoutInMillis) != 5000 {
+		t.Errorf("integration timeout = %v, want 5000", integration.Spec.TimeoutInMillis)
+	}
+
+	route := byName["pets-api-get-pets"].(*svcapitypes.Route)
+	if refName(route.Spec.APIRef) != "pets-api" || refName(route.Spec.TargetRef) != "pets-api-in1" ||
+		refName(route.Spec.AuthorizerRef) != "pets-api-cognito" {
+		t.Errorf("route refs = %v %v %v", route.Spec.APIRef, route.Spec.TargetRef, route.Spec.AuthorizerRef)
+	}
+	if route.Spec.Target != nil || route.Spec.AuthorizerID != nil || route.Spec.APIID != nil {
+		t.Errorf("route spec = %+v, want the IDs replaced by references", route.Spec)
+	}
+
+	if stage := byName["pets-api-prod"].(*svcapitypes.Stage); refName(stage.Spec.DeploymentRef) != "pets-api-d1" {
+		t.Errorf("stage deploymentRef = %v, want pets-api-d1", stage.Spec.DeploymentRef)
+	}
+	if stage := byName["pets-api-default"].(*svcapitypes.Stage); stage.Spec.DeploymentRef != nil || stage.Spec.DeploymentID != nil {
+		t.Errorf("auto-deployed stage refers to a deployment")
+	}
+
+	mapping := byName["pets-api-pets-example-com"].(*svcapitypes.APIMapping)
+	if refName(mapping.Spec.DomainRef) != "pets-example-com" || refName(mapping.Spec.APIRef) != "pets-api" ||
+		aws.ToString(mapping.Spec.Stage) != "prod" {
+		t.Errorf("API mapping spec = %+v", mapping.Spec)
+	}
+	if got := mapping.Annotations[ackv1alpha1.AnnotationAdoptionFields]; got != `{"apiMappingID":"m1","domainName":"pets.example.com"}` {
+		t.Errorf("API mapping adoption fields = %s", got)
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAML(&buf, objs); err != nil {
+		t.Fatalf("writeYAML() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Count(out, "---\n") != len(objs) || strings.Contains(out, "status:") ||
+		strings.Contains(out, "creationTimestamp") || strings.Contains(out, `""`) {
+		t.Errorf("writeYAML() = %s", out)
+	}
+	if !strings.Contains(out, "apiVersion: apigatewayv2.services.k8s.aws/v1alpha1\nkind: Route\n") {
+		t.Errorf("writeYAML() is missing the type of the resources: %s", out)
+	}
+}
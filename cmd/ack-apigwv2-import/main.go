@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Command ack-apigwv2-import prints the custom resources that adopt an
+// existing API Gateway v2 API, along with its authorizers, integrations,
+// routes, deployments, stages, custom domain name mappings and VPC links,
+// into the apigatewayv2-controller. The custom resources refer to each other
+// with apiRef, authorizerRef, targetRef, connectionRef, deploymentRef and
+// domainRef rather than with the IDs of the AWS resources.
+//
+//	ack-apigwv2-import --api-id a1b2c3d4e5 --namespace pets | kubectl apply -f -
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+func main() {
+	var apiID, namespace, region, endpointURL string
+	flag.StringVar(&apiID, "api-id", "", "ID of the API to import")
+	flag.StringVar(&namespace, "namespace", "default", "Namespace of the custom resources")
+	flag.StringVar(&region, "aws-region", "", "AWS region of the API, defaults to the region of the AWS configuration")
+	flag.StringVar(&endpointURL, "aws-endpoint-url", "", "API Gateway endpoint URL, defaults to the AWS endpoint of the region")
+	flag.Parse()
+
+	if apiID == "" {
+		fmt.Fprintln(os.Stderr, "--api-id is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(context.Background(), apiID, namespace, region, endpointURL, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, apiID, namespace, region, endpointURL string, w io.Writer) error {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("cannot load the AWS configuration: %w", err)
+	}
+	c := svcsdk.NewFromConfig(cfg, func(o *svcsdk.Options) {
+		if endpointURL != "" {
+			o.BaseEndpoint = aws.String(endpointURL)
+		}
+	})
+
+	objs, err := newImporter(c, namespace).importAPI(ctx, apiID)
+	if err != nil {
+		return err
+	}
+	return writeYAML(w, objs)
+}
+
+// writeYAML writes the custom resources as a multi-document YAML stream,
+// leaving out their empty status and creation timestamp.
+func writeYAML(w io.Writer, objs []client.Object) error {
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		delete(u, "status")
+		unstructured.RemoveNestedField(u, "metadata", "creationTimestamp")
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -39,7 +39,9 @@ import (
 	ctrlrtwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	svctypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	svckubeclient "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
 	svcresource "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource"
+	svcwatch "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/watch"
 
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/api"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/api_mapping"
@@ -47,9 +49,13 @@ import (
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/deployment"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/domain_name"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/integration"
+	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/integration_response"
+	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/model"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/route"
+	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/route_response"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/stage"
 	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/vpc_link"
+	_ "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/webhook"
 
 	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/version"
 )
@@ -207,7 +213,8 @@ func main() {
 		}
 	}
 
-	if err = sc.BindControllerManager(mgr, ackCfg); err != nil {
+	watchMgr := svcwatch.NewManager(mgr)
+	if err = sc.BindControllerManager(watchMgr, ackCfg); err != nil {
 		setupLog.Error(
 			err, "unable bind to controller manager to service controller",
 			"aws.service", awsServiceAlias,
@@ -215,6 +222,46 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = svcwatch.BindAPIBodySources(watchMgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to watch API body sources",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = svcwatch.BindAPIExports(watchMgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to watch API export sources",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = svcwatch.BindRouteKeyConflicts(watchMgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to watch route key conflicts",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = svcwatch.BindDeletionDependents(watchMgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to watch deletion dependents",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = svcwatch.BindReferenceTargets(watchMgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to watch reference targets",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
 	if err = mgr.AddHealthzCheck("health", ctrlrthealthz.Ping); err != nil {
 		setupLog.Error(
 			err, "unable to set up health check",
@@ -234,7 +281,9 @@ func main() {
 		"starting manager",
 		"aws.service", awsServiceAlias,
 	)
-	if err := mgr.Start(stopChan); err != nil {
+	// The resource managers reach the Kubernetes client of the manager through
+	// the context of their reconciliations.
+	if err := mgr.Start(svckubeclient.IntoContext(stopChan, mgr.GetClient())); err != nil {
 		setupLog.Error(
 			err, "unable to start controller manager",
 			"aws.service", awsServiceAlias,
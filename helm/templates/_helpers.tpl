@@ -61,6 +61,13 @@ rules:
   - list
   - patch
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - create
+  - update
 - apiGroups:
   - ""
   resources:
@@ -77,8 +84,11 @@ rules:
   - authorizers
   - deployments
   - domainnames
+  - integrationresponses
   - integrations
+  - models
   - routes
+  - routeresponses
   - stages
   - vpclinks
   verbs:
@@ -97,14 +107,25 @@ rules:
   - authorizers/status
   - deployments/status
   - domainnames/status
+  - integrationresponses/status
   - integrations/status
+  - models/status
   - routes/status
+  - routeresponses/status
   - stages/status
   - vpclinks/status
   verbs:
   - get
   - patch
   - update
+- apiGroups:
+  - lambda.services.k8s.aws
+  resources:
+  - aliases
+  - functions
+  verbs:
+  - get
+  - list
 - apiGroups:
   - services.k8s.aws
   resources:
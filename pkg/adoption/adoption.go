@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package adoption supports the adoption of existing API Gateway resources by
+// their natural keys, such as the name of an API or the route key of a route,
+// in addition to the identifiers generated by API Gateway.
+package adoption
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Requested returns true if the resource carries the adoption-policy
+// annotation and has not been adopted yet. Natural keys are only looked up
+// for such resources, so that a new resource is never silently bound to an
+// existing one that happens to have the same name.
+func Requested(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[ackv1alpha1.AnnotationAdoptionPolicy]; !ok {
+		return false
+	}
+	return !strings.EqualFold(annotations[ackv1alpha1.AnnotationAdopted], "true")
+}
+
+// Unique returns the single identifier of the AWS resources matching a
+// natural key. It returns an empty string if nothing matches, and a terminal
+// error listing the candidates if several resources match, since adopting an
+// arbitrary one of them could hand the wrong resource over to the
+// controller.
+func Unique(kind string, key string, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ids[0], nil
+	}
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	return "", ackerr.NewTerminalError(fmt.Errorf(
+		"cannot adopt %s %s: it matches %d resources (%s), adopt it by ID instead",
+		kind, key, len(sorted), strings.Join(sorted, ", "),
+	))
+}
+
+// NaturalKey returns the value of a natural key field of a resource being
+// adopted: the value of key in the adoption-fields annotation, or value if
+// the annotation does not set it. The runtime reports a malformed annotation
+// itself, so it is ignored here.
+func NaturalKey(obj metav1.Object, key string, value *string) *string {
+	fields := map[string]string{}
+	annotation := obj.GetAnnotations()[ackv1alpha1.AnnotationAdoptionFields]
+	if err := json.Unmarshal([]byte(annotation), &fields); err != nil || fields[key] == "" {
+		return value
+	}
+	v := fields[key]
+	return &v
+}
@@ -0,0 +1,98 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package adoption
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequested(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{"not adopting", nil, false},
+		{"adopting", map[string]string{ackv1alpha1.AnnotationAdoptionPolicy: "adopt"}, true},
+		{"already adopted", map[string]string{
+			ackv1alpha1.AnnotationAdoptionPolicy: "adopt-or-create",
+			ackv1alpha1.AnnotationAdopted:        "true",
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Requested(&metav1.ObjectMeta{Annotations: tt.annotations}); got != tt.want {
+				t.Errorf("Requested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNaturalKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{"from the annotation", `{"apiID": "", "name": "orders"}`, "orders"},
+		{"empty in the annotation", `{"apiID": "", "name": ""}`, "pets"},
+		{"missing from the annotation", `{"apiID": ""}`, "pets"},
+		{"malformed annotation", `{"name": `, "pets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: map[string]string{
+				ackv1alpha1.AnnotationAdoptionFields: tt.fields,
+			}}
+			if got := aws.ToString(NaturalKey(obj, "name", aws.String("pets"))); got != tt.want {
+				t.Errorf("NaturalKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name         string
+		ids          []string
+		wantID       string
+		wantTerminal bool
+	}{
+		{"none", nil, "", false},
+		{"one", []string{"a1"}, "a1", false},
+		{"several", []string{"a2", "a1"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unique("API", `"pets"`, tt.ids)
+			var terminal *ackerr.TerminalError
+			if errors.As(err, &terminal) != tt.wantTerminal {
+				t.Fatalf("Unique() error = %v, wantTerminal %v", err, tt.wantTerminal)
+			}
+			if got != tt.wantID {
+				t.Errorf("Unique() = %q, want %q", got, tt.wantID)
+			}
+			if tt.wantTerminal && !strings.Contains(err.Error(), "a1, a2") {
+				t.Errorf("Unique() error = %q, want the candidates listed", err)
+			}
+		})
+	}
+}
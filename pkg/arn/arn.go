@@ -0,0 +1,44 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package arn builds the ARNs of API Gateway resources.
+package arn
+
+import (
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// APIGateway returns the ARN of the API Gateway resource in the partition
+// and region whose resource path is pathFormat with each %s replaced by the
+// next of the ids, e.g. "/apis/%s/routes/%s". Returns nil while any of the
+// ids is not known.
+func APIGateway(
+	partition ackv1alpha1.AWSPartition,
+	region ackv1alpha1.AWSRegion,
+	pathFormat string,
+	ids ...*string,
+) *ackv1alpha1.AWSResourceName {
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			return nil
+		}
+		args = append(args, *id)
+	}
+	arn := ackv1alpha1.AWSResourceName(fmt.Sprintf(
+		"arn:%s:apigateway:%s::"+pathFormat, append([]any{partition, region}, args...)...,
+	))
+	return &arn
+}
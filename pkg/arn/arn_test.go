@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package arn
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestAPIGateway(t *testing.T) {
+	tests := []struct {
+		name       string
+		pathFormat string
+		ids        []*string
+		want       string
+	}{
+		{name: "API", pathFormat: "/apis/%s", ids: []*string{aws.String("a1")}, want: "arn:aws:apigateway:us-west-2::/apis/a1"},
+		{
+			name:       "route response",
+			pathFormat: "/apis/%s/routes/%s/routeresponses/%s",
+			ids:        []*string{aws.String("a1"), aws.String("r1"), aws.String("rr1")},
+			want:       "arn:aws:apigateway:us-west-2::/apis/a1/routes/r1/routeresponses/rr1",
+		},
+		{name: "unknown ID", pathFormat: "/apis/%s/routes/%s", ids: []*string{aws.String("a1"), nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := APIGateway("aws", "us-west-2", tt.pathFormat, tt.ids...)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("APIGateway() = %q, want nil", *got)
+				}
+				return
+			}
+			if got == nil || string(*got) != tt.want {
+				t.Errorf("APIGateway() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package fake provides a fake Kubernetes client, holding the core and
+// apigatewayv2 objects, for the tests of the resource managers.
+package fake
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+)
+
+// Scheme holds the core and apigatewayv2 types.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(Scheme))
+	utilruntime.Must(svcapitypes.AddToScheme(Scheme))
+}
+
+// NewClient returns a fake Kubernetes client holding the objects.
+func NewClient(objs ...client.Object) client.Client {
+	return ctrlfake.NewClientBuilder().WithScheme(Scheme).WithObjects(objs...).Build()
+}
+
+// NewContext returns a copy of ctx carrying a fake Kubernetes client holding
+// the objects.
+func NewContext(ctx context.Context, objs ...client.Object) context.Context {
+	return kubeclient.IntoContext(ctx, NewClient(objs...))
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package kubeclient passes the Kubernetes client of the controller manager to
+// the resource managers. The resource managers are generated and built by the
+// ACK runtime, which does not hand them a Kubernetes client, so the client is
+// carried by the context of the reconciliations instead.
+package kubeclient
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type contextKey struct{}
+
+// IntoContext returns a copy of ctx carrying kc.
+func IntoContext(ctx context.Context, kc client.Client) context.Context {
+	return context.WithValue(ctx, contextKey{}, kc)
+}
+
+// FromContext returns the Kubernetes client carried by ctx. An error is
+// returned when ctx carries none, so that the checks needing a client fail
+// rather than being skipped.
+func FromContext(ctx context.Context) (client.Client, error) {
+	kc, ok := ctx.Value(contextKey{}).(client.Client)
+	if !ok || kc == nil {
+		return nil, errors.New("no Kubernetes client in the reconciliation context")
+	}
+	return kc, nil
+}
@@ -0,0 +1,256 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svclambda "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svclambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+const (
+	invokeAction        = "lambda:InvokeFunction"
+	apiGatewayPrincipal = "apigateway.amazonaws.com"
+)
+
+var (
+	// functionARNRegex matches a Lambda function ARN, with an optional
+	// version or alias qualifier, either on its own or embedded in an API
+	// Gateway invocation URI.
+	functionARNRegex = regexp.MustCompile(
+		`arn:(aws[a-zA-Z-]*):lambda:([a-z0-9-]+):(\d{12}):function:[a-zA-Z0-9-_]+(:[a-zA-Z0-9-_$]+)?`,
+	)
+)
+
+type metricsRecorder interface {
+	RecordAPICall(opType string, opID string, err error)
+}
+
+// InvokePermission is the lambda:InvokeFunction permission granted to API
+// Gateway for an integration or authorizer with ManageInvokePermission set.
+type InvokePermission struct {
+	// Kind is the kind of the resource in error messages, e.g. "integration".
+	Kind string
+	// Manage is the ManageInvokePermission field of the resource.
+	Manage *bool
+	// Type is the type of the resource, e.g. its IntegrationType. The
+	// permission is managed only for resources of SupportedType.
+	Type          *string
+	SupportedType string
+	// URI is the URI of the function, held in the field named URIField.
+	URIField string
+	URI      *string
+	// StatementID identifies the permission in the function policy.
+	StatementID string
+	// SourceARN restricts the permission to the given execute-api ARN.
+	SourceARN string
+}
+
+// DesiredFunction returns the ARN of the Lambda function that API Gateway
+// must be allowed to invoke, or an empty string when the permission is not
+// managed.
+func (p InvokePermission) DesiredFunction() (string, error) {
+	if p.Manage == nil || !*p.Manage {
+		return "", nil
+	}
+	if aws.ToString(p.Type) != p.SupportedType {
+		return "", ackerr.NewTerminalError(fmt.Errorf(
+			"ManageInvokePermission is supported only for %s %ss", p.SupportedType, p.Kind,
+		))
+	}
+	functionARN, ok := FunctionARNFromURI(aws.ToString(p.URI))
+	if !ok {
+		return "", ackerr.NewTerminalError(fmt.Errorf(
+			"ManageInvokePermission requires %s to point at a Lambda function", p.URIField,
+		))
+	}
+	return functionARN, nil
+}
+
+// InSync returns true if the permission is granted on the desired function
+// only, given the function it is currently granted on.
+func (p InvokePermission) InSync(granted *string) bool {
+	want, err := p.DesiredFunction()
+	return err == nil && want == aws.ToString(granted)
+}
+
+// FunctionARNFromURI returns the Lambda function ARN contained in an
+// integration or authorizer URI. Both plain function ARNs and API Gateway
+// invocation URIs are supported. Returns false if the URI does not point at
+// a Lambda function.
+func FunctionARNFromURI(uri string) (string, bool) {
+	arn := functionARNRegex.FindString(uri)
+	return arn, arn != ""
+}
+
+// InvocationURI returns the API Gateway URI that invokes the Lambda function
+// with the given ARN, e.g.
+// arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/{arn}/invocations,
+// as expected by the URI of a REQUEST authorizer. The partition and region are
+// the ones of the function.
+func InvocationURI(functionARN string) (string, error) {
+	m := functionARNRegex.FindStringSubmatch(functionARN)
+	if m == nil || m[0] != functionARN {
+		return "", fmt.Errorf("invalid Lambda function ARN %q", functionARN)
+	}
+	return fmt.Sprintf(
+		"arn:%s:apigateway:%s:lambda:path/2015-03-31/functions/%s/invocations",
+		m[1], m[2], functionARN,
+	), nil
+}
+
+// ExecuteAPIARN returns the execute-api ARN of the given resource path of an
+// API, e.g. "*/*" for any stage and route or "authorizers/{id}".
+func ExecuteAPIARN(partition, region, accountID, apiID, path string) string {
+	return fmt.Sprintf("arn:%s:execute-api:%s:%s:%s/%s", partition, region, accountID, apiID, path)
+}
+
+// SyncInvokePermission grants API Gateway the permission to invoke the
+// desired function and removes the permission previously granted on another
+// function. Returns the function the permission is granted on afterwards,
+// which is the one given if nothing changed.
+func SyncInvokePermission(
+	ctx context.Context,
+	cfg aws.Config,
+	mr metricsRecorder,
+	p InvokePermission,
+	granted *string,
+) (*string, error) {
+	want, err := p.DesiredFunction()
+	if err != nil {
+		return granted, err
+	}
+	if want == aws.ToString(granted) {
+		return granted, nil
+	}
+	if granted, err = RemoveInvokePermission(ctx, cfg, mr, p, granted); err != nil {
+		return granted, err
+	}
+	if want == "" {
+		return nil, nil
+	}
+	if err := addPermission(ctx, cfg, mr, want, p); err != nil {
+		return nil, err
+	}
+	return &want, nil
+}
+
+// RemoveInvokePermission removes the permission granted on the given
+// function, if any. Returns the function the permission is granted on
+// afterwards.
+func RemoveInvokePermission(
+	ctx context.Context,
+	cfg aws.Config,
+	mr metricsRecorder,
+	p InvokePermission,
+	granted *string,
+) (*string, error) {
+	if granted == nil {
+		return nil, nil
+	}
+	if err := removePermission(ctx, cfg, mr, *granted, p.StatementID); err != nil {
+		return granted, err
+	}
+	return nil, nil
+}
+
+// addPermission grants API Gateway the permission to invoke the function. An
+// existing permission with the same statement ID is left as is.
+func addPermission(
+	ctx context.Context,
+	cfg aws.Config,
+	mr metricsRecorder,
+	functionARN string,
+	p InvokePermission,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("lambda.addPermission")
+	defer func() {
+		exit(err)
+	}()
+
+	client, err := newClient(cfg, functionARN)
+	if err != nil {
+		return err
+	}
+	_, err = client.AddPermission(ctx, &svclambda.AddPermissionInput{
+		Action:       aws.String(invokeAction),
+		FunctionName: aws.String(functionARN),
+		Principal:    aws.String(apiGatewayPrincipal),
+		SourceArn:    aws.String(p.SourceARN),
+		StatementId:  aws.String(p.StatementID),
+	})
+	mr.RecordAPICall("UPDATE", "AddPermission", err)
+	var conflict *svclambdatypes.ResourceConflictException
+	if errors.As(err, &conflict) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot grant API Gateway permission to invoke %s: %w", functionARN, err)
+	}
+	return nil
+}
+
+// removePermission removes the permission with the given statement ID from
+// the function policy. A missing function or permission is not an error.
+func removePermission(
+	ctx context.Context,
+	cfg aws.Config,
+	mr metricsRecorder,
+	functionARN string,
+	statementID string,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("lambda.removePermission")
+	defer func() {
+		exit(err)
+	}()
+
+	client, err := newClient(cfg, functionARN)
+	if err != nil {
+		return err
+	}
+	_, err = client.RemovePermission(ctx, &svclambda.RemovePermissionInput{
+		FunctionName: aws.String(functionARN),
+		StatementId:  aws.String(statementID),
+	})
+	mr.RecordAPICall("UPDATE", "RemovePermission", err)
+	var notFound *svclambdatypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot remove API Gateway permission to invoke %s: %w", functionARN, err)
+	}
+	return nil
+}
+
+// newClient returns a Lambda client for the region of the function that uses
+// the client configuration of the service controller.
+func newClient(cfg aws.Config, functionARN string) (*svclambda.Client, error) {
+	m := functionARNRegex.FindStringSubmatch(functionARN)
+	if m == nil {
+		return nil, fmt.Errorf("invalid Lambda function ARN %q", functionARN)
+	}
+	return svclambda.NewFromConfig(cfg, func(o *svclambda.Options) {
+		o.Region = m[2]
+	}), nil
+}
@@ -0,0 +1,279 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lambda
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestFunctionARNFromURI(t *testing.T) {
+	const arn = "arn:aws:lambda:us-west-2:123456789012:function:authorizer"
+	tests := []struct {
+		name   string
+		uri    string
+		want   string
+		wantOK bool
+	}{
+		{"function ARN", arn, arn, true},
+		{"qualified function ARN", arn + ":live", arn + ":live", true},
+		{
+			"invocation URI",
+			"arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/" + arn + "/invocations",
+			arn, true,
+		},
+		{"HTTP URI", "https://example.com/pets", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FunctionARNFromURI(tt.uri)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FunctionARNFromURI() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInvocationURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		arn     string
+		want    string
+		wantErr bool
+	}{
+		{
+			"function ARN",
+			"arn:aws:lambda:us-west-2:123456789012:function:authorizer",
+			"arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:us-west-2:123456789012:function:authorizer/invocations",
+			false,
+		},
+		{
+			"qualified function ARN in another partition",
+			"arn:aws-cn:lambda:cn-north-1:123456789012:function:authorizer:live",
+			"arn:aws-cn:apigateway:cn-north-1:lambda:path/2015-03-31/functions/arn:aws-cn:lambda:cn-north-1:123456789012:function:authorizer:live/invocations",
+			false,
+		},
+		{"invocation URI", "arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:us-west-2:123456789012:function:authorizer/invocations", "", true},
+		{"not a function", "https://example.com/auth", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InvocationURI(tt.arn)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("InvocationURI() = (%q, %v), want (%q, wantErr %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// policyTransport serves the Lambda AddPermission and RemovePermission calls,
+// failing them with the error types in errs, keyed by HTTP method.
+type policyTransport struct {
+	errs  map[string]string
+	calls []string
+	body  string
+}
+
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls = append(t.calls, req.Method+" "+req.URL.Host+req.URL.EscapedPath())
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		t.body = string(b)
+	}
+	resp := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"Statement":"{}"}`)),
+		Request:    req,
+	}
+	switch errType := t.errs[req.Method]; errType {
+	case "":
+		if req.Method == http.MethodDelete {
+			resp.StatusCode = http.StatusNoContent
+			resp.Body = http.NoBody
+		}
+	case "ResourceConflictException":
+		resp.StatusCode = http.StatusConflict
+	case "ResourceNotFoundException":
+		resp.StatusCode = http.StatusNotFound
+	default:
+		resp.StatusCode = http.StatusBadRequest
+	}
+	if errType := t.errs[req.Method]; errType != "" {
+		resp.Header.Set("X-Amzn-ErrorType", errType)
+		resp.Body = io.NopCloser(strings.NewReader(`{"Type":"User","message":"failed"}`))
+	}
+	return resp, nil
+}
+
+func TestSyncInvokePermission(t *testing.T) {
+	const (
+		west = "arn:aws:lambda:us-west-2:123456789012:function:west"
+		east = "arn:aws:lambda:us-east-1:123456789012:function:east"
+	)
+	tests := []struct {
+		name         string
+		manage       bool
+		typ          string
+		uri          string
+		granted      *string
+		errs         map[string]string
+		want         *string
+		wantCalls    []string
+		wantErr      bool
+		wantTerminal bool
+	}{
+		{
+			name: "not managed",
+			uri:  west,
+		},
+		{
+			name:      "granted",
+			manage:    true,
+			uri:       west,
+			want:      aws.String(west),
+			wantCalls: []string{"POST lambda.us-west-2.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(west, ":", "%3A") + "/policy"},
+		},
+		{
+			name:      "already granted",
+			manage:    true,
+			uri:       west,
+			errs:      map[string]string{http.MethodPost: "ResourceConflictException"},
+			want:      aws.String(west),
+			wantCalls: []string{"POST lambda.us-west-2.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(west, ":", "%3A") + "/policy"},
+		},
+		{
+			name:    "in sync",
+			manage:  true,
+			uri:     west,
+			granted: aws.String(west),
+			want:    aws.String(west),
+		},
+		{
+			name:    "moved",
+			manage:  true,
+			uri:     west,
+			granted: aws.String(east),
+			want:    aws.String(west),
+			wantCalls: []string{
+				"DELETE lambda.us-east-1.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(east, ":", "%3A") + "/policy/apigatewayv2-integration-i1",
+				"POST lambda.us-west-2.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(west, ":", "%3A") + "/policy",
+			},
+		},
+		{
+			name:    "removed from a deleted function",
+			uri:     west,
+			granted: aws.String(east),
+			errs:    map[string]string{http.MethodDelete: "ResourceNotFoundException"},
+			wantCalls: []string{
+				"DELETE lambda.us-east-1.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(east, ":", "%3A") + "/policy/apigatewayv2-integration-i1",
+			},
+		},
+		{
+			name:    "removal failed",
+			uri:     west,
+			granted: aws.String(east),
+			errs:    map[string]string{http.MethodDelete: "AccessDeniedException"},
+			want:    aws.String(east),
+			wantCalls: []string{
+				"DELETE lambda.us-east-1.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(east, ":", "%3A") + "/policy/apigatewayv2-integration-i1",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "grant failed after the removal",
+			manage:  true,
+			uri:     west,
+			granted: aws.String(east),
+			errs:    map[string]string{http.MethodPost: "AccessDeniedException"},
+			wantCalls: []string{
+				"DELETE lambda.us-east-1.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(east, ":", "%3A") + "/policy/apigatewayv2-integration-i1",
+				"POST lambda.us-west-2.amazonaws.com/2015-03-31/functions/" + strings.ReplaceAll(west, ":", "%3A") + "/policy",
+			},
+			wantErr: true,
+		},
+		{
+			name:         "unsupported type",
+			manage:       true,
+			typ:          "HTTP_PROXY",
+			uri:          west,
+			granted:      aws.String(west),
+			want:         aws.String(west),
+			wantErr:      true,
+			wantTerminal: true,
+		},
+		{
+			name:         "not a function",
+			manage:       true,
+			uri:          "https://example.com/pets",
+			wantErr:      true,
+			wantTerminal: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &policyTransport{errs: tt.errs}
+			cfg := aws.Config{
+				Region:     "eu-west-1",
+				HTTPClient: &http.Client{Transport: transport},
+				Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+					return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+				}),
+				RetryMaxAttempts: 1,
+			}
+			typ := tt.typ
+			if typ == "" {
+				typ = "AWS_PROXY"
+			}
+			p := InvokePermission{
+				Kind:          "integration",
+				Manage:        aws.Bool(tt.manage),
+				Type:          aws.String(typ),
+				SupportedType: "AWS_PROXY",
+				URIField:      "IntegrationURI",
+				URI:           aws.String(tt.uri),
+				StatementID:   "apigatewayv2-integration-i1",
+				SourceARN:     ExecuteAPIARN("aws", "us-west-2", "123456789012", "a1", "*/*"),
+			}
+
+			got, err := SyncInvokePermission(context.Background(), cfg, ackmetrics.NewMetrics("apigatewayv2"), p, tt.granted)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncInvokePermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var terminal *ackerr.TerminalError
+			if errors.As(err, &terminal) != tt.wantTerminal {
+				t.Errorf("SyncInvokePermission() error = %v, wantTerminal %v", err, tt.wantTerminal)
+			}
+			if aws.ToString(got) != aws.ToString(tt.want) {
+				t.Errorf("SyncInvokePermission() = %q, want %q", aws.ToString(got), aws.ToString(tt.want))
+			}
+			if strings.Join(transport.calls, "\n") != strings.Join(tt.wantCalls, "\n") {
+				t.Errorf("calls = %q, want %q", transport.calls, tt.wantCalls)
+			}
+			if !tt.wantErr && tt.want != nil && len(tt.wantCalls) > 0 &&
+				!strings.Contains(transport.body, `"SourceArn":"arn:aws:execute-api:us-west-2:123456789012:a1/*/*"`) {
+				t.Errorf("AddPermission body = %s, want the permission scoped to the API", transport.body)
+			}
+		})
+	}
+}
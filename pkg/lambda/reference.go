@@ -0,0 +1,99 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package lambda resolves references to the Function and Alias resources of
+// the ACK Lambda controller and manages the permissions that allow API Gateway
+// to invoke Lambda functions. The resources are read as unstructured objects so
+// that the controller does not depend on the Lambda controller's API module.
+package lambda
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	// FunctionGVK identifies the Function resource of the ACK Lambda
+	// controller.
+	FunctionGVK = schema.GroupVersionKind{
+		Group:   "lambda.services.k8s.aws",
+		Version: "v1alpha1",
+		Kind:    "Function",
+	}
+	// AliasGVK identifies the Alias resource of the ACK Lambda controller.
+	AliasGVK = schema.GroupVersionKind{
+		Group:   "lambda.services.k8s.aws",
+		Version: "v1alpha1",
+		Kind:    "Alias",
+	}
+)
+
+// ReferencedARN looks up the Lambda resource of the given kind and returns
+// its ARN. Like the generated getReferencedResourceState functions, it
+// returns `ackerr.ResourceReferenceTerminalFor` or
+// `ackerr.ResourceReferenceNotSyncedFor` when the resource is Terminal or not
+// synced yet.
+func ReferencedARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	gvk schema.GroupVersionKind,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) (string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	if err := apiReader.Get(ctx, namespacedName, obj); err != nil {
+		return "", err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if hasCondition(conditions, ackv1alpha1.ConditionTypeTerminal) {
+		return "", ackerr.ResourceReferenceTerminalFor(gvk.Kind, namespace, name)
+	}
+	if !hasCondition(conditions, ackv1alpha1.ConditionTypeResourceSynced) {
+		return "", ackerr.ResourceReferenceNotSyncedFor(gvk.Kind, namespace, name)
+	}
+	arn, _, _ := unstructured.NestedString(obj.Object, "status", "ackResourceMetadata", "arn")
+	if arn == "" {
+		return "", ackerr.ResourceReferenceMissingTargetFieldFor(
+			gvk.Kind, namespace, name, "Status.ACKResourceMetadata.ARN",
+		)
+	}
+	return arn, nil
+}
+
+// hasCondition returns true if the unstructured conditions contain a
+// condition of the given type with status True.
+func hasCondition(conditions []interface{}, condType ackv1alpha1.ConditionType) bool {
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == string(condType) && cond["status"] == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
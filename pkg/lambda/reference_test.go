@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lambda
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFunction(name string, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	obj.SetGroupVersionKind(FunctionGVK)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func condition(condType string) map[string]interface{} {
+	return map[string]interface{}{"type": condType, "status": "True"}
+}
+
+func TestReferencedARN(t *testing.T) {
+	const arn = "arn:aws:lambda:us-west-2:123456789012:function:synced"
+	kc := fake.NewClientBuilder().WithObjects(
+		newFunction("synced", map[string]interface{}{
+			"ackResourceMetadata": map[string]interface{}{"arn": arn},
+			"conditions":          []interface{}{condition("ACK.ResourceSynced")},
+		}),
+		newFunction("pending", map[string]interface{}{}),
+		newFunction("terminal", map[string]interface{}{
+			"conditions": []interface{}{condition("ACK.Terminal")},
+		}),
+	).Build()
+
+	tests := []struct {
+		name    string
+		wantARN string
+		wantErr bool
+	}{
+		{"synced", arn, false},
+		{"pending", "", true},
+		{"terminal", "", true},
+		{"missing", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReferencedARN(context.Background(), kc, FunctionGVK, tt.name, "default")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReferencedARN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantARN {
+				t.Errorf("ReferencedARN() = %q, want %q", got, tt.wantARN)
+			}
+		})
+	}
+}
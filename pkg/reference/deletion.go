@@ -0,0 +1,113 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationDeletionCascade, when set to "true" on a resource, makes its
+	// deletion delete the resources that refer to it first.
+	AnnotationDeletionCascade = "apigatewayv2.services.k8s.aws/deletion-cascade"
+
+	// ConditionTypeDeletionBlocked is set on a resource being deleted while
+	// other resources still refer to it. Its message lists them.
+	ConditionTypeDeletionBlocked ackv1alpha1.ConditionType = "DeletionBlocked"
+)
+
+// CheckDeletion returns an error requeueing the deletion of obj, a resource
+// of the given kind, while other resources refer to it, so that API Gateway
+// resources are not deleted from under their dependents. The dependents are
+// looked up, and deleted on cascade, with kc. The DeletionBlocked condition
+// is set in conditions with the list of the dependents. When obj has the
+// deletion-cascade annotation, the dependents are deleted as well.
+func CheckDeletion(
+	ctx context.Context,
+	kc client.Client,
+	kind string,
+	obj metav1.Object,
+	conditions *[]*ackv1alpha1.Condition,
+) error {
+	target := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	dependents, err := Dependents(ctx, kc, kind, target)
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	if obj.GetAnnotations()[AnnotationDeletionCascade] == "true" {
+		for _, d := range dependents {
+			if d.Object.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if err := kc.Delete(ctx, d.Object); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("cannot delete %s: %w", d, err)
+			}
+			now := metav1.Now()
+			d.Object.SetDeletionTimestamp(&now)
+		}
+	}
+
+	blockers := make([]string, 0, len(dependents))
+	for _, d := range dependents {
+		blockers = append(blockers, d.String())
+	}
+	msg := fmt.Sprintf(
+		"deletion of %s %s/%s waits for the %d resource(s) referring to it: %s",
+		kind, target.Namespace, target.Name, len(dependents), strings.Join(blockers, ", "),
+	)
+	setCondition(conditions, ConditionTypeDeletionBlocked, corev1.ConditionTrue, "DependentsExist", msg)
+	return ackrequeue.NeededAfter(errors.New(msg), ackrequeue.DefaultRequeueAfterDuration)
+}
+
+// setCondition sets the condition of the given type in conditions, adding
+// it if missing.
+func setCondition(
+	conditions *[]*ackv1alpha1.Condition,
+	condType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	msg string,
+) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range *conditions {
+		if c.Type == condType {
+			cond = c
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: condType}
+		*conditions = append(*conditions, cond)
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Reason = aws.String(reason)
+	cond.Message = aws.String(msg)
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"strings"
+)
+
+// AnnotationReferenceGrant, set on a resource, lists the namespaces whose
+// resources may refer to it from another namespace, e.g. "apps,payments" or
+// "*" for any namespace. An entry of the form "apps/Route" only grants the
+// resources of the given kind in the namespace. Resources may always refer to
+// the resources of their own namespace; references to another namespace are
+// denied unless the referenced resource grants them.
+const AnnotationReferenceGrant = "apigatewayv2.services.k8s.aws/reference-grant"
+
+// Granted returns true if the reference-grant annotation value grant lets a
+// resource of the given kind in the given namespace refer to the annotated
+// resource.
+func Granted(grant string, namespace string, kind string) bool {
+	for _, entry := range strings.Split(grant, ",") {
+		entry = strings.TrimSpace(entry)
+		ns, k, hasKind := strings.Cut(entry, "/")
+		if ns != "*" && ns != namespace {
+			continue
+		}
+		if !hasKind || k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import "testing"
+
+func TestGranted(t *testing.T) {
+	tests := []struct {
+		grant     string
+		namespace string
+		kind      string
+		want      bool
+	}{
+		{"", "apps", "Route", false},
+		{"apps", "apps", "Route", true},
+		{"payments, apps", "apps", "Route", true},
+		{"payments", "apps", "Route", false},
+		{"*", "apps", "Route", true},
+		{"apps/Route", "apps", "Route", true},
+		{"apps/Route", "apps", "Integration", false},
+		{"*/Integration, apps/Route", "apps", "Integration", true},
+	}
+	for _, tt := range tests {
+		if got := Granted(tt.grant, tt.namespace, tt.kind); got != tt.want {
+			t.Errorf("Granted(%q, %q, %q) = %v, want %v", tt.grant, tt.namespace, tt.kind, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package reference describes the reference fields through which the
+// apigatewayv2 resources refer to each other, e.g. the targetRef of a Route to
+// an Integration, and finds the resources that refer to a given resource.
+package reference
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+)
+
+// Field is a reference field of a resource kind.
+type Field struct {
+	// Kind is the kind of the resources holding the field, e.g. "Route".
+	Kind string
+	// Path is the path of the field, e.g. "spec.targetRef".
+	Path string
+	// Target is the kind of the resources the field refers to, e.g.
+	// "Integration".
+	Target string
+	// TargetField is the path of the field of the target the reference
+	// resolves to, e.g. "status.integrationID".
+	TargetField string
+
+	// targetGVK is set for targets outside of the apigatewayv2 API group.
+	targetGVK schema.GroupVersionKind
+
+	newObject func() client.Object
+	newList   func() client.ObjectList
+	get       func(client.Object) *ackv1alpha1.AWSResourceReferenceWrapper
+}
+
+// Fields are the reference fields of the apigatewayv2 resources, to each
+// other and to the Lambda functions and aliases they integrate with.
+var Fields = []Field{
+	{
+		Kind: "APIMapping", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.APIMapping{} },
+		newList:     func() client.ObjectList { return &svcapitypes.APIMappingList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.APIMapping).Spec.APIRef
+		},
+	},
+	{
+		Kind: "APIMapping", Path: "spec.domainRef", Target: "DomainName",
+		TargetField: "spec.domainName",
+		newObject:   func() client.Object { return &svcapitypes.APIMapping{} },
+		newList:     func() client.ObjectList { return &svcapitypes.APIMappingList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.APIMapping).Spec.DomainRef
+		},
+	},
+	{
+		Kind: "Authorizer", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Authorizer{} },
+		newList:     func() client.ObjectList { return &svcapitypes.AuthorizerList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Authorizer).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Authorizer", Path: "spec.functionRef", Target: "Function",
+		TargetField: "status.ackResourceMetadata.arn",
+		targetGVK:   lambda.FunctionGVK,
+		newObject:   func() client.Object { return &svcapitypes.Authorizer{} },
+		newList:     func() client.ObjectList { return &svcapitypes.AuthorizerList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Authorizer).Spec.FunctionRef
+		},
+	},
+	{
+		Kind: "Deployment", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Deployment{} },
+		newList:     func() client.ObjectList { return &svcapitypes.DeploymentList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Deployment).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Integration", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Integration{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Integration).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Integration", Path: "spec.connectionRef", Target: "VPCLink",
+		TargetField: "status.vpcLinkID",
+		newObject:   func() client.Object { return &svcapitypes.Integration{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Integration).Spec.ConnectionRef
+		},
+	},
+	{
+		Kind: "Integration", Path: "spec.functionRef", Target: "Function",
+		TargetField: "status.ackResourceMetadata.arn",
+		targetGVK:   lambda.FunctionGVK,
+		newObject:   func() client.Object { return &svcapitypes.Integration{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Integration).Spec.FunctionRef
+		},
+	},
+	{
+		Kind: "Integration", Path: "spec.functionAliasRef", Target: "Alias",
+		TargetField: "status.ackResourceMetadata.arn",
+		targetGVK:   lambda.AliasGVK,
+		newObject:   func() client.Object { return &svcapitypes.Integration{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Integration).Spec.FunctionAliasRef
+		},
+	},
+	{
+		Kind: "IntegrationResponse", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.IntegrationResponse{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationResponseList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.IntegrationResponse).Spec.APIRef
+		},
+	},
+	{
+		Kind: "IntegrationResponse", Path: "spec.integrationRef", Target: "Integration",
+		TargetField: "status.integrationID",
+		newObject:   func() client.Object { return &svcapitypes.IntegrationResponse{} },
+		newList:     func() client.ObjectList { return &svcapitypes.IntegrationResponseList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.IntegrationResponse).Spec.IntegrationRef
+		},
+	},
+	{
+		Kind: "Model", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Model{} },
+		newList:     func() client.ObjectList { return &svcapitypes.ModelList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Model).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Route", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Route{} },
+		newList:     func() client.ObjectList { return &svcapitypes.RouteList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Route).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Route", Path: "spec.authorizerRef", Target: "Authorizer",
+		TargetField: "status.authorizerID",
+		newObject:   func() client.Object { return &svcapitypes.Route{} },
+		newList:     func() client.ObjectList { return &svcapitypes.RouteList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Route).Spec.AuthorizerRef
+		},
+	},
+	{
+		Kind: "Route", Path: "spec.targetRef", Target: "Integration",
+		TargetField: "status.integrationID",
+		newObject:   func() client.Object { return &svcapitypes.Route{} },
+		newList:     func() client.ObjectList { return &svcapitypes.RouteList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Route).Spec.TargetRef
+		},
+	},
+	{
+		Kind: "RouteResponse", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.RouteResponse{} },
+		newList:     func() client.ObjectList { return &svcapitypes.RouteResponseList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.RouteResponse).Spec.APIRef
+		},
+	},
+	{
+		Kind: "RouteResponse", Path: "spec.routeRef", Target: "Route",
+		TargetField: "status.routeID",
+		newObject:   func() client.Object { return &svcapitypes.RouteResponse{} },
+		newList:     func() client.ObjectList { return &svcapitypes.RouteResponseList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.RouteResponse).Spec.RouteRef
+		},
+	},
+	{
+		Kind: "Stage", Path: "spec.apiRef", Target: "API",
+		TargetField: "status.apiID",
+		newObject:   func() client.Object { return &svcapitypes.Stage{} },
+		newList:     func() client.ObjectList { return &svcapitypes.StageList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Stage).Spec.APIRef
+		},
+	},
+	{
+		Kind: "Stage", Path: "spec.deploymentRef", Target: "Deployment",
+		TargetField: "status.deploymentID",
+		newObject:   func() client.Object { return &svcapitypes.Stage{} },
+		newList:     func() client.ObjectList { return &svcapitypes.StageList{} },
+		get: func(obj client.Object) *ackv1alpha1.AWSResourceReferenceWrapper {
+			return obj.(*svcapitypes.Stage).Spec.DeploymentRef
+		},
+	},
+}
+
+// TargetGVK returns the group, version and kind of the resources the field
+// refers to.
+func (f Field) TargetGVK() schema.GroupVersionKind {
+	if !f.targetGVK.Empty() {
+		return f.targetGVK
+	}
+	return svcapitypes.GroupVersion.WithKind(f.Target)
+}
+
+// NewObject returns an empty resource of the kind holding the field.
+func (f Field) NewObject() client.Object {
+	return f.newObject()
+}
+
+// TargetOf returns the namespace and name of the resource obj refers to with
+// the field, or false if the field is not set. The namespace defaults to the
+// one of obj.
+func (f Field) TargetOf(obj client.Object) (types.NamespacedName, bool) {
+	ref := f.get(obj)
+	if ref == nil || ref.From == nil || ref.From.Name == nil || *ref.From.Name == "" {
+		return types.NamespacedName{}, false
+	}
+	namespace := obj.GetNamespace()
+	if ref.From.Namespace != nil && *ref.From.Namespace != "" {
+		namespace = *ref.From.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: *ref.From.Name}, true
+}
+
+// Dependent is a resource that refers to another one.
+type Dependent struct {
+	// Object is the referring resource.
+	Object client.Object
+	// Field is the reference field of Object.
+	Field Field
+}
+
+// String returns the kind, namespace and name of the dependent with its
+// reference field, e.g. "Route default/pets (spec.targetRef)".
+func (d Dependent) String() string {
+	s := fmt.Sprintf("%s %s/%s (%s)", d.Field.Kind, d.Object.GetNamespace(), d.Object.GetName(), d.Field.Path)
+	if d.Object.GetDeletionTimestamp() != nil {
+		s += " being deleted"
+	}
+	return s
+}
+
+// Referrers returns the resources in any namespace that refer to the
+// resource with the given namespace and name with the field.
+func (f Field) Referrers(
+	ctx context.Context,
+	kr client.Reader,
+	target types.NamespacedName,
+) ([]client.Object, error) {
+	list := f.newList()
+	if err := kr.List(ctx, list); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	var referrers []client.Object
+	for _, item := range items {
+		obj := item.(client.Object)
+		if t, ok := f.TargetOf(obj); ok && t == target {
+			referrers = append(referrers, obj)
+		}
+	}
+	return referrers, nil
+}
+
+// Dependents returns the resources in any namespace that refer to the
+// resource of the given kind, namespace and name.
+func Dependents(
+	ctx context.Context,
+	kr client.Reader,
+	kind string,
+	target types.NamespacedName,
+) ([]Dependent, error) {
+	var dependents []Dependent
+	for _, f := range Fields {
+		if f.Target != kind {
+			continue
+		}
+		referrers, err := f.Referrers(ctx, kr, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range referrers {
+			dependents = append(dependents, Dependent{Object: obj, Field: f})
+		}
+	}
+	return dependents, nil
+}
@@ -0,0 +1,194 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+func ref(namespace, name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	r := &ackv1alpha1.AWSResourceReferenceWrapper{From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)}}
+	if namespace != "" {
+		r.From.Namespace = aws.String(namespace)
+	}
+	return r
+}
+
+func newRoute(namespace, name string, apiRef, targetRef *ackv1alpha1.AWSResourceReferenceWrapper) *svcapitypes.Route {
+	return &svcapitypes.Route{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       svcapitypes.RouteSpec{APIRef: apiRef, TargetRef: targetRef},
+	}
+}
+
+func newKubeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestFields(t *testing.T) {
+	for _, f := range Fields {
+		obj := f.NewObject()
+		if kind := reflect.TypeOf(obj).Elem().Name(); kind != f.Kind {
+			t.Errorf("%s %s: object of kind %s", f.Kind, f.Path, kind)
+		}
+		list := reflect.TypeOf(f.newList()).Elem().Name()
+		if list != f.Kind+"List" {
+			t.Errorf("%s %s: list of kind %s", f.Kind, f.Path, list)
+		}
+		if _, ok := f.TargetOf(obj); ok {
+			t.Errorf("%s %s: target of an empty object", f.Kind, f.Path)
+		}
+	}
+}
+
+func TestDependents(t *testing.T) {
+	kr := newKubeClient(t,
+		newRoute("default", "same-namespace", ref("", "pets"), ref("", "pets")),
+		newRoute("apps", "other-namespace", ref("default", "pets"), nil),
+		newRoute("apps", "local-api", ref("", "pets"), nil),
+		newRoute("default", "other-api", ref("", "cats"), nil),
+		&svcapitypes.Stage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod"},
+			Spec:       svcapitypes.StageSpec{APIRef: ref("", "pets")},
+		},
+	)
+
+	tests := []struct {
+		kind string
+		want []string
+	}{
+		{
+			kind: "API",
+			want: []string{
+				"Route apps/other-namespace (spec.apiRef)",
+				"Route default/same-namespace (spec.apiRef)",
+				"Stage default/prod (spec.apiRef)",
+			},
+		},
+		{
+			kind: "Integration",
+			want: []string{"Route default/same-namespace (spec.targetRef)"},
+		},
+		{
+			kind: "VPCLink",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			dependents, err := Dependents(context.Background(), kr, tt.kind, types.NamespacedName{Namespace: "default", Name: "pets"})
+			if err != nil {
+				t.Fatalf("Dependents() error = %v", err)
+			}
+			var got []string
+			for _, d := range dependents {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dependents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDeletion(t *testing.T) {
+	integration := func(annotations map[string]string) *svcapitypes.Integration {
+		return &svcapitypes.Integration{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "pets",
+			Annotations: annotations,
+		}}
+	}
+	route := func() *svcapitypes.Route {
+		return newRoute("default", "pets", nil, ref("", "pets"))
+	}
+
+	tests := []struct {
+		name          string
+		kc            client.Client
+		obj           *svcapitypes.Integration
+		wantBlocked   bool
+		wantRouteGone bool
+	}{
+		{
+			name: "no dependents",
+			kc:   newKubeClient(t),
+			obj:  integration(nil),
+		},
+		{
+			name:        "targeted by a route",
+			kc:          newKubeClient(t, route()),
+			obj:         integration(nil),
+			wantBlocked: true,
+		},
+		{
+			name:          "cascade",
+			kc:            newKubeClient(t, route()),
+			obj:           integration(map[string]string{AnnotationDeletionCascade: "true"}),
+			wantBlocked:   true,
+			wantRouteGone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckDeletion(context.Background(), tt.kc, "Integration", tt.obj, &tt.obj.Status.Conditions)
+			var requeue *ackrequeue.RequeueNeededAfter
+			if blocked := errors.As(err, &requeue); blocked != tt.wantBlocked {
+				t.Fatalf("CheckDeletion() error = %v, want blocked %v", err, tt.wantBlocked)
+			}
+			var cond *ackv1alpha1.Condition
+			for _, c := range tt.obj.Status.Conditions {
+				if c.Type == ConditionTypeDeletionBlocked {
+					cond = c
+				}
+			}
+			if (cond != nil) != tt.wantBlocked {
+				t.Fatalf("DeletionBlocked condition = %v, want %v", cond, tt.wantBlocked)
+			}
+			if cond != nil {
+				if cond.Status != corev1.ConditionTrue || !strings.Contains(*cond.Message, "Route default/pets (spec.targetRef)") {
+					t.Errorf("DeletionBlocked = %s %q, want the route listed", cond.Status, *cond.Message)
+				}
+			}
+			if !tt.wantBlocked {
+				return
+			}
+			err = tt.kc.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "pets"}, &svcapitypes.Route{})
+			if gone := apierrors.IsNotFound(err); gone != tt.wantRouteGone {
+				t.Errorf("route deleted = %v, want %v", gone, tt.wantRouteGone)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionTypeReferencesResolved is set on every resource with reference
+// fields. Unlike ACK.ReferencesResolved, which only carries the error of the
+// first reference that failed to resolve, its message lists the state of
+// each reference field and the resource it refers to.
+const ConditionTypeReferencesResolved ackv1alpha1.ConditionType = "ReferencesResolved"
+
+// State is the state of the resource a reference field refers to.
+type State string
+
+const (
+	// StateResolved means the target is synced and has the field the
+	// reference resolves to.
+	StateResolved State = "resolved"
+	// StateMissing means the target does not exist.
+	StateMissing State = "missing"
+	// StateTerminal means the target is in a terminal state.
+	StateTerminal State = "terminal"
+	// StateNotSynced means the target is not synced yet.
+	StateNotSynced State = "not synced"
+	// StateMissingField means the target is synced but lacks the field the
+	// reference resolves to.
+	StateMissingField State = "missing status field"
+	// StateNotGranted means the target is in another namespace and does not
+	// grant the reference with its reference-grant annotation.
+	StateNotGranted State = "not granted"
+)
+
+// Resolution is the state of the target of a reference field.
+type Resolution struct {
+	// Field is the reference field.
+	Field Field
+	// Target is the namespace and name of the resource the field refers to.
+	Target types.NamespacedName
+	// State is the state of the target.
+	State State
+}
+
+// String returns the field with its target and state, e.g.
+// "spec.targetRef: Integration default/pets not synced".
+func (r Resolution) String() string {
+	s := fmt.Sprintf("%s: %s %s %s", r.Field.Path, r.Field.Target, r.Target, r.State)
+	if r.State == StateMissingField {
+		s += " " + r.Field.TargetField
+	}
+	return s
+}
+
+// Resolutions returns the state of the targets of the reference fields of
+// obj, a resource of the given kind. Fields that are not set are skipped.
+func Resolutions(
+	ctx context.Context,
+	kr client.Reader,
+	kind string,
+	obj client.Object,
+) ([]Resolution, error) {
+	var resolutions []Resolution
+	for _, f := range Fields {
+		if f.Kind != kind {
+			continue
+		}
+		target, ok := f.TargetOf(obj)
+		if !ok {
+			continue
+		}
+		state, err := stateOf(ctx, kr, f, obj.GetNamespace(), target)
+		if err != nil {
+			return nil, err
+		}
+		resolutions = append(resolutions, Resolution{Field: f, Target: target, State: state})
+	}
+	return resolutions, nil
+}
+
+// stateOf reads the target of the field, held by a resource of the given
+// namespace, and returns its state.
+func stateOf(
+	ctx context.Context,
+	kr client.Reader,
+	f Field,
+	namespace string,
+	target types.NamespacedName,
+) (State, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(f.TargetGVK())
+	if err := kr.Get(ctx, target, u); err != nil {
+		if apierrors.IsNotFound(err) {
+			return StateMissing, nil
+		}
+		return "", err
+	}
+	if target.Namespace != namespace &&
+		!Granted(u.GetAnnotations()[AnnotationReferenceGrant], namespace, f.Kind) {
+		return StateNotGranted, nil
+	}
+	state, _ := resolvedState(f, u.Object)
+	return state, nil
+}
+
+// ResolvedValue returns the value of the field of target, a resource the
+// field refers to, that the reference resolves to, or an empty string while
+// the reference cannot be resolved.
+func (f Field) ResolvedValue(target client.Object) string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(target)
+	if err != nil {
+		return ""
+	}
+	state, value := resolvedState(f, content)
+	if state != StateResolved {
+		return ""
+	}
+	return value
+}
+
+// resolvedState returns the state of the target of the field, given as
+// unstructured content, and the value the reference resolves to.
+func resolvedState(f Field, content map[string]interface{}) (State, string) {
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+	if conditionTrue(conditions, ackv1alpha1.ConditionTypeTerminal) {
+		return StateTerminal, ""
+	}
+	if !conditionTrue(conditions, ackv1alpha1.ConditionTypeResourceSynced) {
+		return StateNotSynced, ""
+	}
+	value, _, _ := unstructured.NestedString(content, strings.Split(f.TargetField, ".")...)
+	if value == "" {
+		return StateMissingField, ""
+	}
+	return StateResolved, value
+}
+
+// conditionTrue returns true if the unstructured conditions contain a
+// condition of the given type with status True.
+func conditionTrue(conditions []interface{}, condType ackv1alpha1.ConditionType) bool {
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == string(condType) && cond["status"] == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetResolvedCondition sets the ReferencesResolved condition of obj, a
+// resource of the given kind, in conditions. The condition is True when the
+// targets of all the reference fields of obj are resolved, and is removed
+// when obj has no reference fields set. When a reference to another namespace
+// is not granted, the ACK.Terminal condition is set as well and
+// ackerr.Terminal is returned, so that the reference is only retried once the
+// target changes.
+func SetResolvedCondition(
+	ctx context.Context,
+	kr client.Reader,
+	kind string,
+	obj client.Object,
+	conditions *[]*ackv1alpha1.Condition,
+) error {
+	resolutions, err := Resolutions(ctx, kr, kind, obj)
+	if err != nil {
+		return err
+	}
+	if len(resolutions) == 0 {
+		removeCondition(conditions, ConditionTypeReferencesResolved)
+		return nil
+	}
+	status, reason := corev1.ConditionTrue, "Resolved"
+	states := make([]string, 0, len(resolutions))
+	var denied []string
+	for _, r := range resolutions {
+		if r.State != StateResolved {
+			status, reason = corev1.ConditionFalse, "Unresolved"
+		}
+		if r.State == StateNotGranted {
+			denied = append(denied, fmt.Sprintf("%s %s", r.Field.Target, r.Target))
+		}
+		states = append(states, r.String())
+	}
+	setCondition(conditions, ConditionTypeReferencesResolved, status, reason, strings.Join(states, "; "))
+	// A resource being deleted still resolves its references, so that the
+	// deletion of its AWS resource is not prevented by a revoked grant.
+	if len(denied) > 0 && obj.GetDeletionTimestamp() == nil {
+		msg := fmt.Sprintf(
+			"references from namespace %s are not granted by the %s annotation of %s",
+			obj.GetNamespace(), AnnotationReferenceGrant, strings.Join(denied, ", "),
+		)
+		setCondition(conditions, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionTrue, "ReferenceNotGranted", msg)
+		return ackerr.Terminal
+	}
+	return nil
+}
+
+// removeCondition removes the condition of the given type from conditions.
+func removeCondition(conditions *[]*ackv1alpha1.Condition, condType ackv1alpha1.ConditionType) {
+	kept := (*conditions)[:0]
+	for _, c := range *conditions {
+		if c.Type != condType {
+			kept = append(kept, c)
+		}
+	}
+	*conditions = kept
+}
@@ -0,0 +1,242 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+)
+
+func synced() []*ackv1alpha1.Condition {
+	return []*ackv1alpha1.Condition{{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}}
+}
+
+func TestSetResolvedCondition(t *testing.T) {
+	function := &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "ACK.Terminal", "status": "True"}},
+	}}}
+	function.SetGroupVersionKind(lambda.FunctionGVK)
+	function.SetNamespace("default")
+	function.SetName("handler")
+
+	kr := newKubeClient(t,
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Status:     svcapitypes.APIStatus{APIID: aws.String("a1b2c3"), Conditions: synced()},
+		},
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "no-id"},
+			Status:     svcapitypes.APIStatus{Conditions: synced()},
+		},
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "platform",
+				Name:        "shared",
+				Annotations: map[string]string{AnnotationReferenceGrant: "default/Route"},
+			},
+			Status: svcapitypes.APIStatus{APIID: aws.String("d4e5f6"), Conditions: synced()},
+		},
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "private"},
+			Status:     svcapitypes.APIStatus{APIID: aws.String("g7h8i9"), Conditions: synced()},
+		},
+		&svcapitypes.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending"},
+		},
+		&svcapitypes.VPCLink{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Status:     svcapitypes.VPCLinkStatus{VPCLinkID: aws.String("l1"), Conditions: synced()},
+		},
+		function,
+	)
+
+	route := func(apiRef, targetRef *ackv1alpha1.AWSResourceReferenceWrapper) *svcapitypes.Route {
+		return newRoute("default", "pets", apiRef, targetRef)
+	}
+	for _, tt := range []struct {
+		name         string
+		route        *svcapitypes.Route
+		wantStatus   corev1.ConditionStatus
+		wantMsg      string
+		wantTerminal bool
+	}{
+		{
+			name:  "no references",
+			route: route(nil, nil),
+		},
+		{
+			name:       "resolved",
+			route:      route(ref("", "pets"), nil),
+			wantStatus: corev1.ConditionTrue,
+			wantMsg:    "spec.apiRef: API default/pets resolved",
+		},
+		{
+			name:       "not synced",
+			route:      route(ref("", "pets"), ref("", "pending")),
+			wantStatus: corev1.ConditionFalse,
+			wantMsg:    "spec.apiRef: API default/pets resolved; spec.targetRef: Integration default/pending not synced",
+		},
+		{
+			name:       "missing",
+			route:      route(ref("apps", "pets"), ref("", "pets")),
+			wantStatus: corev1.ConditionFalse,
+			wantMsg:    "spec.apiRef: API apps/pets missing; spec.targetRef: Integration default/pets missing",
+		},
+		{
+			name:       "missing status field",
+			route:      route(ref("", "no-id"), nil),
+			wantStatus: corev1.ConditionFalse,
+			wantMsg:    "spec.apiRef: API default/no-id missing status field status.apiID",
+		},
+		{
+			name:       "granted",
+			route:      route(ref("platform", "shared"), nil),
+			wantStatus: corev1.ConditionTrue,
+			wantMsg:    "spec.apiRef: API platform/shared resolved",
+		},
+		{
+			name:         "not granted",
+			route:        route(ref("platform", "private"), nil),
+			wantStatus:   corev1.ConditionFalse,
+			wantMsg:      "spec.apiRef: API platform/private not granted",
+			wantTerminal: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := tt.route
+			ko.Status.Conditions = []*ackv1alpha1.Condition{{
+				Type:   ConditionTypeReferencesResolved,
+				Status: corev1.ConditionUnknown,
+			}}
+			err := SetResolvedCondition(context.Background(), kr, "Route", ko, &ko.Status.Conditions)
+			if (err == ackerr.Terminal) != tt.wantTerminal || err != nil && err != ackerr.Terminal {
+				t.Fatalf("SetResolvedCondition() error = %v, want terminal %v", err, tt.wantTerminal)
+			}
+			assertResolvedCondition(t, ko.Status.Conditions, tt.wantStatus, tt.wantMsg)
+			if got := terminal(ko.Status.Conditions); got != tt.wantTerminal {
+				t.Errorf("ACK.Terminal = %v, want %v", got, tt.wantTerminal)
+			}
+		})
+	}
+
+	t.Run("not granted while being deleted", func(t *testing.T) {
+		ko := route(ref("platform", "private"), nil)
+		now := metav1.Now()
+		ko.DeletionTimestamp = &now
+		if err := SetResolvedCondition(context.Background(), kr, "Route", ko, &ko.Status.Conditions); err != nil {
+			t.Fatalf("SetResolvedCondition() error = %v", err)
+		}
+		if terminal(ko.Status.Conditions) {
+			t.Errorf("ACK.Terminal set on a route being deleted")
+		}
+	})
+
+	t.Run("lambda function", func(t *testing.T) {
+		ko := &svcapitypes.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Spec: svcapitypes.IntegrationSpec{
+				APIRef:        ref("", "pets"),
+				ConnectionRef: ref("", "pets"),
+				FunctionRef:   ref("", "handler"),
+			},
+		}
+		if err := SetResolvedCondition(context.Background(), kr, "Integration", ko, &ko.Status.Conditions); err != nil {
+			t.Fatalf("SetResolvedCondition() error = %v", err)
+		}
+		assertResolvedCondition(t, ko.Status.Conditions, corev1.ConditionFalse,
+			"spec.apiRef: API default/pets resolved; "+
+				"spec.connectionRef: VPCLink default/pets resolved; "+
+				"spec.functionRef: Function default/handler terminal")
+	})
+}
+
+// terminal returns true if conditions hold a True ACK.Terminal condition.
+func terminal(conditions []*ackv1alpha1.Condition) bool {
+	for _, c := range conditions {
+		if c.Type == ackv1alpha1.ConditionTypeTerminal && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// assertResolvedCondition checks the ReferencesResolved condition in
+// conditions, which must be absent when wantStatus is empty.
+func assertResolvedCondition(t *testing.T, conditions []*ackv1alpha1.Condition, wantStatus corev1.ConditionStatus, wantMsg string) {
+	t.Helper()
+	var cond *ackv1alpha1.Condition
+	for _, c := range conditions {
+		if c.Type == ConditionTypeReferencesResolved {
+			cond = c
+		}
+	}
+	if wantStatus == "" {
+		if cond != nil {
+			t.Errorf("ReferencesResolved = %s %q, want none", cond.Status, *cond.Message)
+		}
+		return
+	}
+	if cond == nil {
+		t.Fatalf("ReferencesResolved not set")
+	}
+	if cond.Status != wantStatus || *cond.Message != wantMsg {
+		t.Errorf("ReferencesResolved = %s %q, want %s %q", cond.Status, *cond.Message, wantStatus, wantMsg)
+	}
+}
+
+func TestResolvedValue(t *testing.T) {
+	var f Field
+	for _, field := range Fields {
+		if field.Kind == "Route" && field.Path == "spec.targetRef" {
+			f = field
+		}
+	}
+	integration := func(id *string, conditions []*ackv1alpha1.Condition) *svcapitypes.Integration {
+		return &svcapitypes.Integration{Status: svcapitypes.IntegrationStatus{IntegrationID: id, Conditions: conditions}}
+	}
+
+	tests := []struct {
+		name   string
+		target *svcapitypes.Integration
+		want   string
+	}{
+		{"not synced", integration(aws.String("i1"), nil), ""},
+		{"synced", integration(aws.String("i1"), synced()), "i1"},
+		{"synced without ID", integration(nil, synced()), ""},
+		{"terminal", integration(aws.String("i1"), []*ackv1alpha1.Condition{{
+			Type:   ackv1alpha1.ConditionTypeTerminal,
+			Status: corev1.ConditionTrue,
+		}}), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.ResolvedValue(tt.target); got != tt.want {
+				t.Errorf("ResolvedValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/adoption"
+)
+
+// resolveNaturalKey sets the ID of an API being adopted by name and
+// protocol type. The name and protocol type are read from the
+// adoption-fields annotation, whose apiID is then left empty, or from the
+// spec. The API is left without an ID, and so reported as not found, if no
+// API matches, while several matching APIs make the adoption fail.
+func (rm *resourceManager) resolveNaturalKey(
+	ctx context.Context,
+	r *resource,
+) (_ *resource, err error) {
+	if aws.ToString(r.ko.Status.APIID) != "" || !adoption.Requested(r.ko) {
+		return r, nil
+	}
+	r = r.DeepCopy().(*resource)
+	r.ko.Status.APIID = nil
+	r.ko.Spec.Name = adoption.NaturalKey(r.ko, "name", r.ko.Spec.Name)
+	r.ko.Spec.ProtocolType = adoption.NaturalKey(r.ko, "protocolType", r.ko.Spec.ProtocolType)
+	if r.ko.Spec.Name == nil {
+		return r, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.resolveNaturalKey")
+	defer func() { exit(err) }()
+
+	var ids []string
+	input := &svcsdk.GetApisInput{}
+	for {
+		resp, err := rm.sdkapi.GetApis(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetApis", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			if aws.ToString(item.Name) != *r.ko.Spec.Name {
+				continue
+			}
+			if r.ko.Spec.ProtocolType != nil && string(item.ProtocolType) != *r.ko.Spec.ProtocolType {
+				continue
+			}
+			ids = append(ids, aws.ToString(item.ApiId))
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	key := fmt.Sprintf("%q", *r.ko.Spec.Name)
+	if r.ko.Spec.ProtocolType != nil {
+		key = fmt.Sprintf("%s (%s)", key, *r.ko.Spec.ProtocolType)
+	}
+	id, err := adoption.Unique("API", key, ids)
+	if err != nil || id == "" {
+		return r, err
+	}
+	r.ko.Status.APIID = aws.String(id)
+	return r, nil
+}
@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// resolveReferenceForBody reads the OpenAPI definition from the ConfigMap or
+// Secret key referenced in BodyFrom and returns a copy of ko with the
+// definition set as Body, so that it is never written back to the spec.
+// Also returns a boolean indicating whether the resource uses BodyFrom, or
+// an error. Body and BodyFrom cannot be both set.
+func resolveReferenceForBody(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.API,
+) (resolved *svcapitypes.API, hasReferences bool, err error) {
+	src := ko.Spec.BodyFrom
+	if src == nil {
+		return ko, false, nil
+	}
+	hasReferences = true
+	if ko.Spec.Body != nil {
+		return ko, hasReferences, ackerr.ResourceReferenceAndIDNotSupportedFor("Body", "BodyFrom")
+	}
+	ko = ko.DeepCopy()
+	namespace := ko.ObjectMeta.GetNamespace()
+
+	switch {
+	case src.ConfigMapKeyRef != nil && src.SecretKeyRef != nil:
+		return ko, hasReferences, fmt.Errorf("only one of BodyFrom.ConfigMapKeyRef and BodyFrom.SecretKeyRef can be set")
+	case src.ConfigMapKeyRef != nil:
+		ref := src.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		err := apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, cm)
+		if err != nil {
+			if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+				return ko, hasReferences, nil
+			}
+			return ko, hasReferences, fmt.Errorf("cannot read API body from ConfigMap %s/%s: %w", namespace, ref.Name, err)
+		}
+		if body, ok := cm.Data[ref.Key]; ok {
+			ko.Spec.Body = &body
+		} else if body, ok := cm.BinaryData[ref.Key]; ok {
+			ko.Spec.Body = ptr(string(body))
+		} else if !isOptional(ref.Optional) {
+			return ko, hasReferences, fmt.Errorf("key %q not found in ConfigMap %s/%s", ref.Key, namespace, ref.Name)
+		}
+	case src.SecretKeyRef != nil:
+		ref := src.SecretKeyRef
+		secret := &corev1.Secret{}
+		err := apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+				return ko, hasReferences, nil
+			}
+			return ko, hasReferences, fmt.Errorf("cannot read API body from Secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		if body, ok := secret.Data[ref.Key]; ok {
+			ko.Spec.Body = ptr(string(body))
+		} else if !isOptional(ref.Optional) {
+			return ko, hasReferences, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, namespace, ref.Name)
+		}
+	default:
+		return ko, hasReferences, fmt.Errorf("one of BodyFrom.ConfigMapKeyRef and BodyFrom.SecretKeyRef must be set")
+	}
+	return ko, hasReferences, nil
+}
+
+// clearResolvedBody unsets the Body that was read from BodyFrom, so that the
+// definition is not written to the spec of the resource. The imported
+// definition is tracked by Status.BodyHash.
+func clearResolvedBody(ko *svcapitypes.API) {
+	if ko.Spec.BodyFrom != nil {
+		ko.Spec.Body = nil
+	}
+}
+
+// customPreCompare adds a Spec.Body difference when the desired OpenAPI
+// definition does not match the one that was last imported. GetApi does not
+// return the definition, so the hash recorded in Status.BodyHash is the
+// only way to notice that Body, or the object in BodyFrom, has changed.
+//
+// It also adds a Spec.Export difference when the exported definition of the
+// API is out of date, so that the update exports it again.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if exportOutdated(b.ko) {
+		delta.Add("Spec.Export", a.ko.Spec.Export, b.ko.Status.ExportHash)
+	}
+	if a.ko.Spec.Body == nil || b.ko.Status.BodyHash == nil {
+		return
+	}
+	if bodyHash(*a.ko.Spec.Body) != *b.ko.Status.BodyHash {
+		delta.Add("Spec.Body", a.ko.Spec.Body, b.ko.Status.BodyHash)
+	}
+}
+
+// bodyHash returns the hex encoded SHA-256 hash of an OpenAPI definition.
+func bodyHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,188 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+)
+
+func TestResolveReferenceForBody(t *testing.T) {
+	kc := kubefake.NewClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Data:       map[string]string{"openapi.yaml": petsDefinition},
+			BinaryData: map[string][]byte{"openapi.bin": []byte(petsDefinitionV2)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Data:       map[string][]byte{"openapi.yaml": []byte(petsDefinitionV2)},
+		},
+	)
+	configMapKey := func(name, key string, optional bool) *svcapitypes.APIBodySource {
+		return &svcapitypes.APIBodySource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             aws.Bool(optional),
+		}}
+	}
+	secretKey := func(name, key string, optional bool) *svcapitypes.APIBodySource {
+		return &svcapitypes.APIBodySource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             aws.Bool(optional),
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		body        *string
+		bodyFrom    *svcapitypes.APIBodySource
+		wantHasRefs bool
+		wantBody    *string
+		wantErr     bool
+	}{
+		{name: "no BodyFrom", body: aws.String(petsDefinition), wantBody: aws.String(petsDefinition)},
+		{
+			name:        "Body and BodyFrom",
+			body:        aws.String(petsDefinition),
+			bodyFrom:    configMapKey("pets", "openapi.yaml", false),
+			wantHasRefs: true,
+			wantBody:    aws.String(petsDefinition),
+			wantErr:     true,
+		},
+		{
+			name:        "ConfigMap data",
+			bodyFrom:    configMapKey("pets", "openapi.yaml", false),
+			wantHasRefs: true,
+			wantBody:    aws.String(petsDefinition),
+		},
+		{
+			name:        "ConfigMap binary data",
+			bodyFrom:    configMapKey("pets", "openapi.bin", false),
+			wantHasRefs: true,
+			wantBody:    aws.String(petsDefinitionV2),
+		},
+		{
+			name:        "ConfigMap missing",
+			bodyFrom:    configMapKey("orders", "openapi.yaml", false),
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+		{
+			name:        "optional ConfigMap missing",
+			bodyFrom:    configMapKey("orders", "openapi.yaml", true),
+			wantHasRefs: true,
+		},
+		{
+			name:        "ConfigMap key missing",
+			bodyFrom:    configMapKey("pets", "openapi.json", false),
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+		{
+			name:        "optional ConfigMap key missing",
+			bodyFrom:    configMapKey("pets", "openapi.json", true),
+			wantHasRefs: true,
+		},
+		{
+			name:        "Secret data",
+			bodyFrom:    secretKey("pets", "openapi.yaml", false),
+			wantHasRefs: true,
+			wantBody:    aws.String(petsDefinitionV2),
+		},
+		{
+			name:        "Secret missing",
+			bodyFrom:    secretKey("orders", "openapi.yaml", false),
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+		{
+			name:        "optional Secret key missing",
+			bodyFrom:    secretKey("pets", "openapi.json", true),
+			wantHasRefs: true,
+		},
+		{
+			name: "ConfigMap and Secret",
+			bodyFrom: &svcapitypes.APIBodySource{
+				ConfigMapKeyRef: configMapKey("pets", "openapi.yaml", false).ConfigMapKeyRef,
+				SecretKeyRef:    secretKey("pets", "openapi.yaml", false).SecretKeyRef,
+			},
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+		{
+			name:        "no source",
+			bodyFrom:    &svcapitypes.APIBodySource{},
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.API{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+				Spec:       svcapitypes.APISpec{Body: tt.body, BodyFrom: tt.bodyFrom},
+			}
+			resolved, hasRefs, err := resolveReferenceForBody(context.Background(), kc, ko)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveReferenceForBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if hasRefs != tt.wantHasRefs {
+				t.Errorf("resolveReferenceForBody() = %v, want %v", hasRefs, tt.wantHasRefs)
+			}
+			if got, want := aws.ToString(resolved.Spec.Body), aws.ToString(tt.wantBody); got != want {
+				t.Errorf("Body = %q, want %q", got, want)
+			}
+			if got, want := ko.Spec.Body, tt.body; got != want {
+				t.Errorf("Body of the resource = %v, want it unchanged", aws.ToString(got))
+			}
+		})
+	}
+}
+
+func TestCustomPreCompare_Body(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     *string
+		bodyHash *string
+		want     bool
+	}{
+		{name: "not imported", body: nil, bodyHash: nil},
+		{name: "unchanged", body: aws.String(petsDefinition), bodyHash: aws.String(bodyHash(petsDefinition))},
+		{name: "changed", body: aws.String(petsDefinitionV2), bodyHash: aws.String(bodyHash(petsDefinition)), want: true},
+		{name: "no hash recorded", body: aws.String(petsDefinition), bodyHash: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &resource{&svcapitypes.API{Spec: svcapitypes.APISpec{Body: tt.body}}}
+			// latest carries the desired Body, GetApi does not return it.
+			b := &resource{&svcapitypes.API{
+				Spec:   svcapitypes.APISpec{Body: tt.body},
+				Status: svcapitypes.APIStatus{BodyHash: tt.bodyHash},
+			}}
+			delta := newResourceDelta(a, b)
+			if got := delta.DifferentAt("Spec.Body"); got != tt.want {
+				t.Errorf("delta differs at Spec.Body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 	apigatewayv2types "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
@@ -23,15 +26,15 @@ func (rm *resourceManager) customCreateApi(
 	r *resource,
 ) (*resource, error) {
 	// Based on the fields in desired, find whether we need to reimport or update
-	if rm.importFieldsPresent(r.ko) {
-		if err := rm.validateImportApiInputFields(r.ko); err != nil {
+	if importFieldsPresent(r.ko) {
+		if err := validateImportApiInputFields(r.ko); err != nil {
 			return nil, err
 		} else {
 			// import
 			return rm.importApi(ctx, r)
 		}
 	} else {
-		if err := rm.validateCreateApiInputFields(r.ko); err != nil {
+		if err := validateCreateApiInputFields(r.ko); err != nil {
 			return nil, err
 		} else {
 			return nil, nil
@@ -47,25 +50,66 @@ func (rm *resourceManager) customUpdateApi(ctx context.Context,
 	latest *resource,
 	diffReporter *ackcompare.Delta,
 ) (*resource, error) {
+	// The protocol of an imported API comes from its definition and is left
+	// unset in the spec.
+	if desired.ko.Spec.ProtocolType != nil {
+		if immutableFieldChanges := rm.getImmutableFieldChanges(diffReporter); len(immutableFieldChanges) > 0 {
+			msg := fmt.Sprintf("Immutable Spec fields have been modified: %s", strings.Join(immutableFieldChanges, ","))
+			return nil, ackerr.NewTerminalError(errors.New(msg))
+		}
+	}
+
+	// Tags are not part of UpdateApi/ReimportApi and have to be synced
+	// separately. Skip the update call when only the tags or the export
+	// changed.
+	if diffReporter.DifferentAt("Spec.Tags") {
+		if err := rm.syncTags(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+	}
+	if !diffReporter.DifferentExcept("Spec.Tags", "Spec.Export") {
+		updated := &resource{desired.ko.DeepCopy()}
+		if diffReporter.DifferentAt("Spec.Export") {
+			rm.exportAPI(ctx, updated.ko)
+		}
+		clearResolvedBody(updated.ko)
+		return updated, nil
+	}
+
 	// Based on the fields in desired, find whether we need to reimport or update
-	if rm.importFieldsPresent(desired.ko) {
-		if err := rm.validateReimportApiInputFields(desired.ko); err != nil {
+	var updated *resource
+	var err error
+	if importFieldsPresent(desired.ko) {
+		if err = validateReimportApiInputFields(desired.ko); err != nil {
 			return nil, err
 		} else {
-			return rm.reimportApi(ctx, desired)
+			updated, err = rm.reimportApi(ctx, desired)
 		}
 	} else {
-		if err := rm.validateUpdateApiInputFields(desired.ko); err != nil {
+		if err = validateUpdateApiInputFields(desired.ko); err != nil {
 			return nil, err
-		} else {
-			return rm.updateApi(ctx, desired)
 		}
+		// UpdateApi cannot unset the CORS configuration, it has to be
+		// deleted separately when it is removed from the spec.
+		if diffReporter.DifferentAt("Spec.CORSConfiguration") && desired.ko.Spec.CORSConfiguration == nil {
+			if err = rm.deleteCorsConfiguration(ctx, desired); err != nil {
+				return nil, err
+			}
+		}
+		updated, err = rm.updateApi(ctx, desired)
 	}
+	if err != nil {
+		return nil, err
+	}
+	// Export the API again so that the export reflects the update.
+	rm.exportAPI(ctx, updated.ko)
+	clearResolvedBody(updated.ko)
+	return updated, nil
 }
 
 // importFieldsPresent checks for the presence of 'Body', 'Basepath' & 'FailOnWarning' fields
 // in the API resource. When the mentioned fields are present, ImportApi operation is desired over CreateApi
-func (rm *resourceManager) importFieldsPresent(api *v1alpha1.API) bool {
+func importFieldsPresent(api *v1alpha1.API) bool {
 	if api.Spec.Body != nil || api.Spec.Basepath != nil || api.Spec.FailOnWarnings != nil {
 		return true
 	}
@@ -73,7 +117,7 @@ func (rm *resourceManager) importFieldsPresent(api *v1alpha1.API) bool {
 }
 
 // validateImportApiInputFields validates if all the fields are present for a successful 'ImportApi' call
-func (rm *resourceManager) validateImportApiInputFields(api *v1alpha1.API) error {
+func validateImportApiInputFields(api *v1alpha1.API) error {
 	// For import-api, body is a required field
 	if api.Spec.Body == nil {
 		errorMessage := ""
@@ -93,31 +137,46 @@ func (rm *resourceManager) validateImportApiInputFields(api *v1alpha1.API) error
 		return errors.New(errorMessage)
 	} else {
 		// Body field is present.
-		// Check that no other fields except 'Basepath' and 'FailOnWarnings' is present.
-		specCopy := api.Spec.DeepCopy()
-		specCopy.Body = nil
-		specCopy.FailOnWarnings = nil
-		specCopy.Basepath = nil
-		// Tags field is added with ACK default tags by ACK reconciler.
-		//Allow tag field to be present with other ImportApi fields.
-		specCopy.Tags = nil
-		opts := []cmp.Option{cmpopts.EquateEmpty()}
-		if cmp.Equal(*specCopy, v1alpha1.APISpec{}, opts...) {
-			return nil
-		} else {
-			return errors.New("only 'FailOnWarnings' and 'Basepath' fields can be used with 'Body' field")
+		if err := validateImportOnlyFields(api.Spec); err != nil {
+			return err
+		}
+		// Reject definitions that the service would refuse before calling it.
+		if errs := validateOpenAPIBody(*api.Spec.Body); len(errs) > 0 {
+			return ackerr.NewTerminalError(errs.ToAggregate())
 		}
+		return nil
+	}
+}
+
+// validateImportOnlyFields checks that no other fields except 'Basepath' and
+// 'FailOnWarnings' are present along with the body of an import.
+func validateImportOnlyFields(spec v1alpha1.APISpec) error {
+	specCopy := spec.DeepCopy()
+	specCopy.Body = nil
+	specCopy.FailOnWarnings = nil
+	specCopy.Basepath = nil
+	// Body is resolved from BodyFrom when it is set.
+	specCopy.BodyFrom = nil
+	// Export does not take part in the import.
+	specCopy.Export = nil
+	// Tags field is added with ACK default tags by ACK reconciler.
+	//Allow tag field to be present with other ImportApi fields.
+	specCopy.Tags = nil
+	opts := []cmp.Option{cmpopts.EquateEmpty()}
+	if !cmp.Equal(*specCopy, v1alpha1.APISpec{}, opts...) {
+		return errors.New("only 'FailOnWarnings' and 'Basepath' fields can be used with 'Body' field")
 	}
+	return nil
 }
 
 // validateReimportApiInputFields validates if all the fields are present for a successful ReimportApi operation
 // Currently this validation is similar to ImportApi validation.
-func (rm *resourceManager) validateReimportApiInputFields(api *v1alpha1.API) error {
-	return rm.validateImportApiInputFields(api)
+func validateReimportApiInputFields(api *v1alpha1.API) error {
+	return validateImportApiInputFields(api)
 }
 
 // validateCreateApiInputFields validates if all the fields are present for a successful CreateApi operation
-func (rm *resourceManager) validateCreateApiInputFields(api *v1alpha1.API) error {
+func validateCreateApiInputFields(api *v1alpha1.API) error {
 	if api.Spec.Name == nil || api.Spec.ProtocolType == nil {
 		return errors.New("'Name' and 'ProtocolType' are required properties if 'Body' field is not present")
 	}
@@ -126,8 +185,8 @@ func (rm *resourceManager) validateCreateApiInputFields(api *v1alpha1.API) error
 
 // validateUpdateApiInputFields validates if all the fields are present for a successful UpdateApi operation
 // Currently this validation is similar to CreateApi validation.
-func (rm *resourceManager) validateUpdateApiInputFields(api *v1alpha1.API) error {
-	return rm.validateCreateApiInputFields(api)
+func validateUpdateApiInputFields(api *v1alpha1.API) error {
+	return validateCreateApiInputFields(api)
 }
 
 // importApi creates the Api resource by performing ImportApi sdk operation
@@ -156,6 +215,7 @@ func (rm *resourceManager) importApi(ctx context.Context, desired *resource) (*r
 	if resp.ApiId != nil {
 		ko.Status.APIID = resp.ApiId
 	}
+	ko.Status.BodyHash = aws.String(bodyHash(*desired.ko.Spec.Body))
 	if resp.CreatedDate != nil {
 		ko.Status.CreatedDate = &metav1.Time{*resp.CreatedDate}
 	}
@@ -179,6 +239,9 @@ func (rm *resourceManager) importApi(ctx context.Context, desired *resource) (*r
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
+	rm.exportAPI(ctx, ko)
+	clearResolvedBody(ko)
 
 	return &resource{ko}, nil
 }
@@ -209,6 +272,7 @@ func (rm *resourceManager) reimportApi(ctx context.Context, desired *resource) (
 	if resp.ApiId != nil {
 		ko.Status.APIID = resp.ApiId
 	}
+	ko.Status.BodyHash = aws.String(bodyHash(*desired.ko.Spec.Body))
 	if resp.CreatedDate != nil {
 		ko.Status.CreatedDate = &metav1.Time{*resp.CreatedDate}
 	}
@@ -236,6 +300,17 @@ func (rm *resourceManager) reimportApi(ctx context.Context, desired *resource) (
 	return &resource{ko}, nil
 }
 
+// deleteCorsConfiguration removes the CORS configuration of the API by
+// performing DeleteCorsConfiguration sdk operation
+func (rm *resourceManager) deleteCorsConfiguration(ctx context.Context, desired *resource) error {
+	input := &apigatewayv2.DeleteCorsConfigurationInput{
+		ApiId: desired.ko.Status.APIID,
+	}
+	_, err := rm.sdkapi.DeleteCorsConfiguration(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "DeleteCorsConfiguration", err)
+	return err
+}
+
 // updateApi updates the Api resource's desired state after performing UpdateApi sdk operation
 func (rm *resourceManager) updateApi(ctx context.Context, desired *resource) (*resource, error) {
 	input, err := rm.updateApiInput(desired)
@@ -0,0 +1,87 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestCustomUpdateApi_CORSConfiguration(t *testing.T) {
+	cors := func(origin string) *svcapitypes.CORS {
+		return &svcapitypes.CORS{AllowOrigins: []*string{aws.String(origin)}}
+	}
+
+	tests := []struct {
+		name       string
+		desired    *svcapitypes.CORS
+		latest     *svcapitypes.CORS
+		wantDelete bool
+	}{
+		{"removed", nil, cors("https://example.com"), true},
+		{"changed", cors("https://example.org"), cors("https://example.com"), false},
+		{"added", cors("https://example.com"), nil, false},
+		{"unset", nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fake.NewFixture(t, newResourceManagerFactory())
+			in := &svcsdk.CreateApiInput{Name: aws.String("test"), ProtocolType: svcsdktypes.ProtocolTypeHttp}
+			if tt.latest != nil {
+				in.CorsConfiguration = &svcsdktypes.Cors{AllowOrigins: aws.ToStringSlice(tt.latest.AllowOrigins)}
+			}
+			out, err := f.Client.CreateApi(context.Background(), in)
+			if err != nil {
+				t.Fatalf("CreateApi() error = %v", err)
+			}
+			newAPI := func(cors *svcapitypes.CORS) *resource {
+				return &resource{&svcapitypes.API{
+					Spec: svcapitypes.APISpec{
+						Name:              aws.String("test"),
+						ProtocolType:      aws.String("HTTP"),
+						CORSConfiguration: cors,
+					},
+					Status: svcapitypes.APIStatus{APIID: out.ApiId},
+				}}
+			}
+			desired := newAPI(tt.desired)
+			latest := newAPI(tt.latest)
+			// Force an UpdateApi call even when the CORS configuration
+			// itself does not differ.
+			desired.ko.Spec.Description = aws.String("updated")
+			f.Client.ResetCalls()
+
+			rm := f.Manager.(*resourceManager)
+			if _, err := rm.customUpdateApi(f.Context, desired, latest, newResourceDelta(desired, latest)); err != nil {
+				t.Fatalf("customUpdateApi() error = %v", err)
+			}
+
+			want := []string{"UpdateApi"}
+			if tt.wantDelete {
+				want = []string{"DeleteCorsConfiguration", "UpdateApi"}
+			}
+			if calls := f.Client.Calls(); !reflect.DeepEqual(calls, want) {
+				t.Errorf("calls = %v, want %v", calls, want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package api
 import (
 	"bytes"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
 )
@@ -40,6 +42,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.APIKeySelectionExpression, b.ko.Spec.APIKeySelectionExpression) {
 		delta.Add("Spec.APIKeySelectionExpression", a.ko.Spec.APIKeySelectionExpression, b.ko.Spec.APIKeySelectionExpression)
@@ -55,11 +58,11 @@ func newResourceDelta(
 			delta.Add("Spec.Basepath", a.ko.Spec.Basepath, b.ko.Spec.Basepath)
 		}
 	}
-	if ackcompare.HasNilDifference(a.ko.Spec.Body, b.ko.Spec.Body) {
-		delta.Add("Spec.Body", a.ko.Spec.Body, b.ko.Spec.Body)
-	} else if a.ko.Spec.Body != nil && b.ko.Spec.Body != nil {
-		if equal, err := ackcompare.DocumentEqual(*a.ko.Spec.Body, *b.ko.Spec.Body); err != nil || !equal {
-			delta.Add("Spec.Body", a.ko.Spec.Body, b.ko.Spec.Body)
+	if ackcompare.HasNilDifference(a.ko.Spec.BodyFrom, b.ko.Spec.BodyFrom) {
+		delta.Add("Spec.BodyFrom", a.ko.Spec.BodyFrom, b.ko.Spec.BodyFrom)
+	} else if a.ko.Spec.BodyFrom != nil && b.ko.Spec.BodyFrom != nil {
+		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.BodyFrom, b.ko.Spec.BodyFrom) {
+			delta.Add("Spec.BodyFrom", a.ko.Spec.BodyFrom, b.ko.Spec.BodyFrom)
 		}
 	}
 	if ackcompare.HasNilDifference(a.ko.Spec.CORSConfiguration, b.ko.Spec.CORSConfiguration) {
@@ -136,6 +139,13 @@ func newResourceDelta(
 			delta.Add("Spec.DisableSchemaValidation", a.ko.Spec.DisableSchemaValidation, b.ko.Spec.DisableSchemaValidation)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.Export, b.ko.Spec.Export) {
+		delta.Add("Spec.Export", a.ko.Spec.Export, b.ko.Spec.Export)
+	} else if a.ko.Spec.Export != nil && b.ko.Spec.Export != nil {
+		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.Export, b.ko.Spec.Export) {
+			delta.Add("Spec.Export", a.ko.Spec.Export, b.ko.Spec.Export)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.FailOnWarnings, b.ko.Spec.FailOnWarnings) {
 		delta.Add("Spec.FailOnWarnings", a.ko.Spec.FailOnWarnings, b.ko.Spec.FailOnWarnings)
 	} else if a.ko.Spec.FailOnWarnings != nil && b.ko.Spec.FailOnWarnings != nil {
@@ -0,0 +1,407 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+)
+
+const (
+	defaultExportOutputType    = "JSON"
+	defaultExportSpecification = "OAS30"
+	// ExportHashAnnotation is set on the export ConfigMap to the SHA-256 hash
+	// of the exported definition.
+	ExportHashAnnotation = "apigatewayv2.services.k8s.aws/export-hash"
+
+	// ConditionTypeExported is set on the APIs with Spec.Export set. It is
+	// False when the last export failed or when the exported definition is
+	// out of date.
+	ConditionTypeExported ackv1alpha1.ConditionType = "Exported"
+)
+
+// ExportSource is a resource whose state is part of the exported definition
+// of its API.
+type ExportSource struct {
+	// APIID and APIRef identify the API of the resource.
+	APIID  *string
+	APIRef *ackv1alpha1.AWSResourceReference
+	// ID is the identifier of the resource within its API.
+	ID *string
+}
+
+// ExportSourceOf returns the ExportSource describing obj, and false when obj
+// is not a route, integration, authorizer, model or stage.
+func ExportSourceOf(obj client.Object) (ExportSource, bool) {
+	var src ExportSource
+	var apiRef *ackv1alpha1.AWSResourceReferenceWrapper
+	switch o := obj.(type) {
+	case *svcapitypes.Route:
+		src.APIID, apiRef, src.ID = o.Spec.APIID, o.Spec.APIRef, o.Status.RouteID
+	case *svcapitypes.Integration:
+		src.APIID, apiRef, src.ID = o.Spec.APIID, o.Spec.APIRef, o.Status.IntegrationID
+	case *svcapitypes.Authorizer:
+		src.APIID, apiRef, src.ID = o.Spec.APIID, o.Spec.APIRef, o.Status.AuthorizerID
+	case *svcapitypes.Model:
+		src.APIID, apiRef, src.ID = o.Spec.APIID, o.Spec.APIRef, o.Status.ModelID
+	case *svcapitypes.Stage:
+		src.APIID, apiRef, src.ID = o.Spec.APIID, o.Spec.APIRef, o.Spec.StageName
+	default:
+		return src, false
+	}
+	if apiRef != nil {
+		src.APIRef = apiRef.From
+	}
+	return src, true
+}
+
+// BelongsTo returns true if the resource belongs to the API, referring to it
+// either by name in its namespace or by its API identifier.
+func (s ExportSource) BelongsTo(api *svcapitypes.API) bool {
+	if s.APIRef != nil {
+		return aws.ToString(s.APIRef.Name) == api.Name &&
+			(aws.ToString(s.APIRef.Namespace) == "" || *s.APIRef.Namespace == api.Namespace)
+	}
+	return s.APIID != nil && api.Status.APIID != nil && *s.APIID == *api.Status.APIID
+}
+
+// exportSourceLists returns empty lists of each kind of ExportSource.
+func exportSourceLists() []client.ObjectList {
+	return []client.ObjectList{
+		&svcapitypes.RouteList{},
+		&svcapitypes.IntegrationList{},
+		&svcapitypes.AuthorizerList{},
+		&svcapitypes.ModelList{},
+		&svcapitypes.StageList{},
+	}
+}
+
+// exportInputsHash returns the hash of what the exported definition of the
+// API depends on besides the API itself: the export configuration, and the
+// generation and identifier of the routes, integrations, authorizers, models
+// and stages of the API in its namespace.
+func exportInputsHash(
+	ctx context.Context,
+	kr client.Reader,
+	ko *svcapitypes.API,
+) (string, error) {
+	cfg, err := json.Marshal(ko.Spec.Export)
+	if err != nil {
+		return "", err
+	}
+	var sources []string
+	for _, list := range exportSourceLists() {
+		if err := kr.List(ctx, list, client.InNamespace(ko.Namespace)); err != nil {
+			return "", err
+		}
+		err := meta.EachListItem(list, func(o runtime.Object) error {
+			obj := o.(client.Object)
+			if src, ok := ExportSourceOf(obj); ok && src.BelongsTo(ko) {
+				sources = append(sources, fmt.Sprintf(
+					"%T/%s/%d/%s", obj, obj.GetName(), obj.GetGeneration(), aws.ToString(src.ID),
+				))
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	sort.Strings(sources)
+	return bodyHash(string(cfg) + "\n" + strings.Join(sources, "\n")), nil
+}
+
+// checkExport runs when the API is read. It marks the exported definition out
+// of date in the Exported condition when the inputs of the export changed or
+// the export ConfigMap no longer holds it, so that customPreCompare requests
+// an update, which exports the API again. The export status is dropped from
+// the APIs without Spec.Export.
+func (rm *resourceManager) checkExport(
+	ctx context.Context,
+	ko *svcapitypes.API,
+) {
+	if ko.Spec.Export == nil {
+		clearExport(ko)
+		return
+	}
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		setExportedCondition(ko, corev1.ConditionFalse, "ExportFailed", err.Error())
+		return
+	}
+	hash, err := exportInputsHash(ctx, kc, ko)
+	if err != nil {
+		setExportedCondition(ko, corev1.ConditionFalse, "ExportFailed", err.Error())
+		return
+	}
+	if hash != aws.ToString(ko.Status.ExportInputsHash) {
+		setExportedCondition(ko, corev1.ConditionFalse, "Outdated",
+			"the export configuration or the resources of the API changed since the last export")
+		return
+	}
+	name := aws.ToString(ko.Spec.Export.ConfigMapName)
+	cm, err := exportConfigMapMetadata(ctx, kc, ko.Namespace, name)
+	if err != nil {
+		setExportedCondition(ko, corev1.ConditionFalse, "ExportFailed", err.Error())
+		return
+	}
+	if cm == nil || cm.Annotations[ExportHashAnnotation] != aws.ToString(ko.Status.ExportHash) {
+		setExportedCondition(ko, corev1.ConditionFalse, "Outdated",
+			fmt.Sprintf("ConfigMap %s/%s does not hold the last exported definition", ko.Namespace, name))
+	}
+}
+
+// exportOutdated returns true if the API has Spec.Export set and its exported
+// definition is not known to be up to date.
+func exportOutdated(ko *svcapitypes.API) bool {
+	if ko.Spec.Export == nil {
+		return false
+	}
+	for _, cond := range ko.Status.Conditions {
+		if cond.Type == ConditionTypeExported {
+			return cond.Status != corev1.ConditionTrue
+		}
+	}
+	return true
+}
+
+// exportAPI calls ExportApi and writes the definition to the ConfigMap named
+// in Spec.Export, recording its hash in Status.ExportHash and the hash of the
+// inputs of the export in Status.ExportInputsHash. The outcome is reported in
+// the Exported condition rather than returned, so that a failed export holds
+// back neither the sync nor the deletion of the API. It is retried the next
+// time the API is reconciled.
+func (rm *resourceManager) exportAPI(
+	ctx context.Context,
+	ko *svcapitypes.API,
+) {
+	if ko.Spec.Export == nil {
+		clearExport(ko)
+		return
+	}
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.exportAPI")
+	defer func() { exit(err) }()
+
+	if err = rm.writeExport(ctx, ko); err != nil {
+		setExportedCondition(ko, corev1.ConditionFalse, "ExportFailed", err.Error())
+		return
+	}
+	setExportedCondition(ko, corev1.ConditionTrue, "Exported", fmt.Sprintf(
+		"exported to ConfigMap %s/%s", ko.Namespace, *ko.Spec.Export.ConfigMapName,
+	))
+}
+
+// writeExport exports the definition of the API into the ConfigMap named in
+// Spec.Export. The ConfigMap is only written when its content changes.
+func (rm *resourceManager) writeExport(
+	ctx context.Context,
+	ko *svcapitypes.API,
+) error {
+	cfg := ko.Spec.Export
+	if ko.Spec.ProtocolType != nil && *ko.Spec.ProtocolType == string(svcsdktypes.ProtocolTypeWebsocket) {
+		return errors.New("Export is supported only for HTTP APIs")
+	}
+	if cfg.ConfigMapName == nil || *cfg.ConfigMapName == "" {
+		return errors.New("Export.ConfigMapName is required")
+	}
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	// The inputs are hashed before the export, so that a change made while
+	// exporting is exported again.
+	inputsHash, err := exportInputsHash(ctx, kc, ko)
+	if err != nil {
+		return err
+	}
+
+	outputType := defaultExportOutputType
+	if cfg.OutputType != nil {
+		outputType = *cfg.OutputType
+	}
+	specification := defaultExportSpecification
+	if cfg.Specification != nil {
+		specification = *cfg.Specification
+	}
+	input := &svcsdk.ExportApiInput{
+		ApiId:             ko.Status.APIID,
+		OutputType:        aws.String(outputType),
+		Specification:     aws.String(specification),
+		IncludeExtensions: cfg.IncludeExtensions,
+		StageName:         cfg.StageName,
+	}
+	resp, err := rm.sdkapi.ExportApi(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "ExportApi", err)
+	if err != nil {
+		return err
+	}
+
+	definition := string(resp.Body)
+	hash := bodyHash(definition)
+	if err := writeExportConfigMap(ctx, kc, ko, *cfg.ConfigMapName, exportKey(outputType), definition, hash); err != nil {
+		return err
+	}
+	ko.Status.ExportHash = &hash
+	ko.Status.ExportInputsHash = &inputsHash
+	return nil
+}
+
+// exportKey returns the ConfigMap key holding a definition of the given
+// output type.
+func exportKey(outputType string) string {
+	return "openapi." + strings.ToLower(outputType)
+}
+
+// exportConfigMapMetadata returns the metadata of the export ConfigMap, or
+// nil if it does not exist. Only the metadata is read, so that the controller
+// does not cache the content of every ConfigMap.
+func exportConfigMapMetadata(
+	ctx context.Context,
+	kr client.Reader,
+	namespace string,
+	name string,
+) (*metav1.PartialObjectMetadata, error) {
+	cm := &metav1.PartialObjectMetadata{}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	err := kr.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read export ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return cm, nil
+}
+
+// writeExportConfigMap creates or updates the ConfigMap holding the exported
+// definition. The ConfigMap is owned by the API so that it is garbage
+// collected together with it.
+func writeExportConfigMap(
+	ctx context.Context,
+	kc client.Client,
+	ko *svcapitypes.API,
+	name string,
+	key string,
+	definition string,
+	hash string,
+) error {
+	namespace := ko.ObjectMeta.GetNamespace()
+	existing, err := exportConfigMapMetadata(ctx, kc, namespace, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Annotations[ExportHashAnnotation] == hash {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if existing != nil {
+		cm.ObjectMeta = existing.ObjectMeta
+	}
+	cm.Name = name
+	cm.Namespace = namespace
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[ExportHashAnnotation] = hash
+	cm.Data = map[string]string{key: definition}
+	if !hasOwnerReference(cm, ko) {
+		cm.OwnerReferences = append(cm.OwnerReferences, metav1.OwnerReference{
+			APIVersion: svcapitypes.GroupVersion.String(),
+			Kind:       "API",
+			Name:       ko.Name,
+			UID:        ko.UID,
+		})
+	}
+
+	if existing != nil {
+		err = kc.Update(ctx, cm)
+	} else {
+		err = kc.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write export ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+func hasOwnerReference(cm *corev1.ConfigMap, ko *svcapitypes.API) bool {
+	for _, ref := range cm.OwnerReferences {
+		if ref.UID == ko.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// clearExport drops the export status of an API without Spec.Export.
+func clearExport(ko *svcapitypes.API) {
+	ko.Status.ExportHash = nil
+	ko.Status.ExportInputsHash = nil
+	kept := ko.Status.Conditions[:0]
+	for _, cond := range ko.Status.Conditions {
+		if cond.Type != ConditionTypeExported {
+			kept = append(kept, cond)
+		}
+	}
+	ko.Status.Conditions = kept
+}
+
+// setExportedCondition sets the Exported condition of the API.
+func setExportedCondition(
+	ko *svcapitypes.API,
+	status corev1.ConditionStatus,
+	reason string,
+	msg string,
+) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypeExported {
+			cond = c
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ConditionTypeExported}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Reason = aws.String(reason)
+	cond.Message = aws.String(msg)
+}
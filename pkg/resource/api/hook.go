@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/tags"
+)
+
+// syncTags syncs the tags of the API in AWS with its desired tags.
+func (rm *resourceManager) syncTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	desiredTags, _ := convertToOrderedACKTags(desired.ko.Spec.Tags)
+	latestTags, _ := convertToOrderedACKTags(latest.ko.Spec.Tags)
+	return tags.SyncTags(
+		ctx, rm.sdkapi, rm.metrics, rm.cfg.ResourceTagKeys,
+		latest.ko.Status.ACKResourceMetadata.ARN, desiredTags, latestTags,
+	)
+}
+
+// setResourceARN sets the ARN of the API from its ID.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.API) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s",
+		ko.Status.APIID,
+	)
+}
+
+// checkDependents blocks the deletion of the API while its routes,
+// integrations, stages or other child resources still refer to it with their
+// apiRef, since deleting the API would delete them from under their CRs.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
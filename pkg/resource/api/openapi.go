@@ -0,0 +1,223 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/routekey"
+)
+
+const integrationExtension = "x-amazon-apigateway-integration"
+
+// anyMethodExtension is the path item key API Gateway uses for an ANY route.
+const anyMethodExtension = "x-amazon-apigateway-any-method"
+
+var (
+	// operationKeys are the path item keys that define a route.
+	operationKeys = map[string]bool{
+		"get": true, "put": true, "post": true, "delete": true, "options": true,
+		"head": true, "patch": true, "trace": true, anyMethodExtension: true,
+	}
+	// pathItemKeys are the other path item keys allowed by OpenAPI 3.0.
+	pathItemKeys = map[string]bool{
+		"$ref": true, "summary": true, "description": true, "servers": true,
+		"parameters": true,
+	}
+	integrationTypes      = []string{"AWS_PROXY", "HTTP_PROXY"}
+	connectionTypes       = []string{"INTERNET", "VPC_LINK"}
+	payloadFormatVersions = []string{"1.0", "2.0"}
+)
+
+// validateOpenAPIBody checks, without calling AWS, that body is an OpenAPI
+// 3.0 or Swagger 2.0 definition that ImportApi and ReimportApi can accept:
+// the document structure, the route keys built from its paths and the
+// x-amazon-apigateway-integration extensions of its operations.
+func validateOpenAPIBody(body string) field.ErrorList {
+	root := field.NewPath("spec", "body")
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(body), &doc); err != nil {
+		return field.ErrorList{field.Invalid(root, "", fmt.Sprintf("not a valid JSON or YAML document: %v", err))}
+	}
+
+	var errs field.ErrorList
+	switch version, ok := scalarString(doc["openapi"]); {
+	case doc["openapi"] == nil && doc["swagger"] != nil:
+		if swagger, ok := scalarString(doc["swagger"]); !ok || swagger != "2.0" {
+			errs = append(errs, field.NotSupported(root.Child("swagger"), doc["swagger"], []string{"2.0"}))
+		}
+	case doc["openapi"] == nil:
+		errs = append(errs, field.Required(root.Child("openapi"), "only OpenAPI 3.0 and Swagger 2.0 definitions are supported"))
+	case !ok:
+		errs = append(errs, field.Invalid(root.Child("openapi"), doc["openapi"], "must be a version number"))
+	case !strings.HasPrefix(version, "3.0"):
+		errs = append(errs, field.NotSupported(root.Child("openapi"), version, []string{"3.0.x"}))
+	}
+
+	if info, ok := doc["info"].(map[string]interface{}); !ok {
+		errs = append(errs, field.Required(root.Child("info"), "must be an object"))
+	} else {
+		if title, ok := scalarString(info["title"]); !ok || title == "" {
+			errs = append(errs, field.Required(root.Child("info", "title"), "must be a non-empty string"))
+		}
+		if _, ok := scalarString(info["version"]); !ok {
+			errs = append(errs, field.Required(root.Child("info", "version"), "must be a string or a number"))
+		}
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return append(errs, field.Required(root.Child("paths"), "must be an object"))
+	}
+	for _, path := range sortedKeys(paths) {
+		fldPath := root.Child("paths").Key(path)
+		errs = append(errs, routekey.ValidatePath(fldPath, path)...)
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			errs = append(errs, field.Invalid(fldPath, paths[path], "must be an object"))
+			continue
+		}
+		errs = append(errs, validatePathItem(fldPath, item)...)
+	}
+	return errs
+}
+
+// validatePathItem checks the operations of a path item and their
+// integrations.
+func validatePathItem(fldPath *field.Path, item map[string]interface{}) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range sortedKeys(item) {
+		opPath := fldPath.Child(key)
+		switch {
+		case operationKeys[key]:
+			op, ok := item[key].(map[string]interface{})
+			if !ok {
+				errs = append(errs, field.Invalid(opPath, item[key], "operation must be an object"))
+				continue
+			}
+			if integration, ok := op[integrationExtension]; ok {
+				errs = append(errs, validateIntegration(opPath.Child(integrationExtension), integration)...)
+			}
+		case pathItemKeys[key], strings.HasPrefix(key, "x-"):
+		default:
+			errs = append(errs, field.NotSupported(opPath, key, supportedPathItemKeys()))
+		}
+	}
+	return errs
+}
+
+// validateIntegration checks an x-amazon-apigateway-integration extension
+// against the integrations supported by HTTP APIs.
+func validateIntegration(fldPath *field.Path, value interface{}) field.ErrorList {
+	integration, ok := value.(map[string]interface{})
+	if !ok {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be an object")}
+	}
+	var errs field.ErrorList
+
+	integrationType, ok := integration["type"].(string)
+	if !ok {
+		errs = append(errs, field.Required(fldPath.Child("type"), "must be a string"))
+	} else if !containsFold(integrationTypes, integrationType) {
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), integrationType, integrationTypes))
+	}
+
+	// Service integrations are identified by their subtype instead of a URI.
+	if _, hasSubtype := integration["integrationSubtype"]; !hasSubtype {
+		if uri, ok := integration["uri"].(string); !ok || uri == "" {
+			errs = append(errs, field.Required(fldPath.Child("uri"), "must be a non-empty string"))
+		}
+	}
+
+	if v, ok := integration["payloadFormatVersion"]; ok {
+		if s, ok := scalarString(v); !ok || !containsFold(payloadFormatVersions, s) {
+			errs = append(errs, field.NotSupported(fldPath.Child("payloadFormatVersion"), v, payloadFormatVersions))
+		}
+	}
+
+	connectionType := "INTERNET"
+	if v, ok := integration["connectionType"]; ok {
+		s, ok := v.(string)
+		if !ok || !containsFold(connectionTypes, s) {
+			errs = append(errs, field.NotSupported(fldPath.Child("connectionType"), v, connectionTypes))
+		} else {
+			connectionType = strings.ToUpper(s)
+		}
+	}
+	if connectionType == "VPC_LINK" {
+		if id, ok := integration["connectionId"].(string); !ok || id == "" {
+			errs = append(errs, field.Required(fldPath.Child("connectionId"), "required when connectionType is VPC_LINK"))
+		}
+	}
+
+	if v, ok := integration["timeoutInMillis"]; ok {
+		if n, ok := v.(float64); !ok || n != float64(int64(n)) || n < 50 || n > 30000 {
+			errs = append(errs, field.Invalid(fldPath.Child("timeoutInMillis"), v, "must be an integer between 50 and 30000"))
+		}
+	}
+	return errs
+}
+
+func supportedPathItemKeys() []string {
+	keys := make([]string, 0, len(operationKeys)+len(pathItemKeys)+1)
+	for k := range operationKeys {
+		keys = append(keys, k)
+	}
+	for k := range pathItemKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return append(keys, "x-*")
+}
+
+// scalarString returns the string form of a string or number value. YAML
+// reads unquoted versions such as 2.0 as numbers, which are written back
+// with at least one decimal so that they compare equal to the quoted form.
+func scalarString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) {
+			return strconv.FormatFloat(v, 'f', 1, 64), true
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateOpenAPIBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "OpenAPI 3.0",
+			body: `openapi: 3.0.1
+info:
+  title: pets
+  version: "1"
+paths:
+  /pets/{id}:
+    get:
+      x-amazon-apigateway-integration:
+        type: HTTP_PROXY
+        uri: https://example.com/pets
+        payloadFormatVersion: "1.0"
+        connectionType: VPC_LINK
+        connectionId: link-1
+        timeoutInMillis: 3000
+    x-amazon-apigateway-any-method:
+      x-amazon-apigateway-integration:
+        type: aws_proxy
+        integrationSubtype: SQS-SendMessage
+`,
+		},
+		{
+			name: "OpenAPI 3.0 JSON",
+			body: `{"openapi": "3.0.1", "info": {"title": "pets", "version": "1"}, "paths": {"/pets": {"get": {}}}}`,
+		},
+		{
+			name: "Swagger 2.0",
+			body: `swagger: "2.0"
+info:
+  title: pets
+  version: "1"
+paths:
+  /pets:
+    get:
+      x-amazon-apigateway-integration:
+        type: HTTP_PROXY
+        uri: https://example.com/pets
+`,
+		},
+		{
+			name: "unquoted versions",
+			body: `swagger: 2.0
+info:
+  title: pets
+  version: 1.0
+paths:
+  /pets:
+    get:
+      x-amazon-apigateway-integration:
+        type: HTTP_PROXY
+        uri: https://example.com/pets
+        payloadFormatVersion: 1.0
+    post:
+      x-amazon-apigateway-integration:
+        type: AWS_PROXY
+        uri: arn:aws:lambda:us-west-2:123456789012:function:pets
+        payloadFormatVersion: 2
+`,
+		},
+		{
+			name: "unquoted OpenAPI version",
+			body: "openapi: 3.0\ninfo: {title: pets, version: 2}\npaths: {}\n",
+		},
+		{
+			name: "invalid unquoted versions",
+			body: `swagger: 3
+info:
+  title: pets
+  version: true
+paths:
+  /pets:
+    get:
+      x-amazon-apigateway-integration:
+        type: HTTP_PROXY
+        uri: https://example.com/pets
+        payloadFormatVersion: 1.5
+`,
+			want: []string{
+				"spec.body.swagger: Unsupported value: 3",
+				"spec.body.info.version: Required value",
+				"spec.body.paths[/pets].get.x-amazon-apigateway-integration.payloadFormatVersion: Unsupported value: 1.5",
+			},
+		},
+		{
+			name: "malformed JSON",
+			body: `{"openapi": "3.0.1", "info": {`,
+			want: []string{"spec.body: Invalid value: \"\": not a valid JSON or YAML document"},
+		},
+		{
+			name: "malformed YAML",
+			body: "openapi: 3.0.1\ninfo:\n  title: pets\n version: 1\n",
+			want: []string{"spec.body: Invalid value: \"\": not a valid JSON or YAML document"},
+		},
+		{
+			name: "missing openapi and paths",
+			body: `info: {title: pets, version: "1"}`,
+			want: []string{
+				"spec.body.openapi: Required value",
+				"spec.body.paths: Required value",
+			},
+		},
+		{
+			name: "unsupported OpenAPI version",
+			body: `{"openapi": "3.1.0", "info": {"title": "pets", "version": "1"}, "paths": {}}`,
+			want: []string{`spec.body.openapi: Unsupported value: "3.1.0"`},
+		},
+		{
+			name: "unsupported Swagger version",
+			body: `{"swagger": "1.2", "info": {"title": "pets", "version": "1"}, "paths": {}}`,
+			want: []string{`spec.body.swagger: Unsupported value: "1.2"`},
+		},
+		{
+			name: "missing info",
+			body: `{"openapi": "3.0.1", "info": {"title": ""}, "paths": {}}`,
+			want: []string{
+				"spec.body.info.title: Required value",
+				"spec.body.info.version: Required value",
+			},
+		},
+		{
+			name: "invalid path and integration",
+			body: `openapi: 3.0.1
+info:
+  title: pets
+  version: "1"
+paths:
+  /pets:
+    fetch: {}
+    get:
+      x-amazon-apigateway-integration:
+        type: MOCK
+        connectionType: VPC_LINK
+        timeoutInMillis: 40000
+`,
+			want: []string{
+				`spec.body.paths[/pets].fetch: Unsupported value: "fetch"`,
+				`spec.body.paths[/pets].get.x-amazon-apigateway-integration.type: Unsupported value: "MOCK"`,
+				"spec.body.paths[/pets].get.x-amazon-apigateway-integration.uri: Required value",
+				"spec.body.paths[/pets].get.x-amazon-apigateway-integration.connectionId: Required value",
+				"spec.body.paths[/pets].get.x-amazon-apigateway-integration.timeoutInMillis: Invalid value: 40000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateOpenAPIBody(tt.body)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got errors %v, want %d", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i].Error(), want)
+				}
+			}
+		})
+	}
+}
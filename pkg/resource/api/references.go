@@ -47,6 +47,11 @@ func (rm *resourceManager) ResolveReferences(
 	apiReader client.Reader,
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
+	// read the OpenAPI definition from the ConfigMap or Secret in BodyFrom
+	// into a copy of the resource
+	if ko, hasBodyFrom, err := resolveReferenceForBody(ctx, apiReader, rm.concreteResource(res).ko); hasBodyFrom || err != nil {
+		return &resource{ko}, hasBodyFrom, err
+	}
 	return res, false, nil
 }
 
@@ -64,6 +64,9 @@ func (rm *resourceManager) sdkFind(
 	defer func() {
 		exit(err)
 	}()
+	if r, err = rm.resolveNaturalKey(ctx, r); err != nil {
+		return nil, err
+	}
 	// If any required fields in the input shape are missing, AWS resource is
 	// not created yet. Return NotFound here to indicate to callers that the
 	// resource isn't yet created.
@@ -193,6 +196,9 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
+	rm.checkExport(ctx, ko)
+	clearResolvedBody(ko)
 	return &resource{ko}, nil
 }
 
@@ -354,6 +360,9 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
+	rm.exportAPI(ctx, ko)
+	clearResolvedBody(ko)
 	return &resource{ko}, nil
 }
 
@@ -453,6 +462,9 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err := rm.checkDependents(ctx, r); err != nil {
+		return nil, err
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -583,3 +595,16 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 	// No terminal_errors specified for this resource in generator config
 	return false
 }
+
+// getImmutableFieldChanges returns the immutable fields that differ between
+// the desired and latest state of the resource.
+func (rm *resourceManager) getImmutableFieldChanges(
+	delta *ackcompare.Delta,
+) []string {
+	var fields []string
+	if delta.DifferentAt("Spec.ProtocolType") {
+		fields = append(fields, "ProtocolType")
+	}
+
+	return fields
+}
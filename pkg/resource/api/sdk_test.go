@@ -0,0 +1,507 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+const (
+	petsDefinition = `openapi: 3.0.1
+info:
+  title: pets
+  version: "1.0"
+paths:
+  /pets:
+    get: {}
+    post: {}
+`
+	petsDefinitionV2 = `openapi: 3.0.1
+info:
+  title: pets
+  version: "2.0"
+paths:
+  /pets/{id}:
+    delete: {}
+`
+)
+
+func newHTTPAPI(name string) *resource {
+	return &resource{&svcapitypes.API{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: "uid-" + types.UID(name)},
+		Spec: svcapitypes.APISpec{
+			Name:         aws.String(name),
+			ProtocolType: aws.String("HTTP"),
+		},
+	}}
+}
+
+func newImportedAPI(body string) *resource {
+	return &resource{&svcapitypes.API{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+		Spec:       svcapitypes.APISpec{Body: aws.String(body)},
+	}}
+}
+
+// routeKeys returns the sorted route keys of an API in the fake.
+func routeKeys(f *fake.Fixture, apiID *string) []string {
+	f.T.Helper()
+	out, err := f.Client.GetRoutes(context.Background(), &svcsdk.GetRoutesInput{ApiId: apiID})
+	if err != nil {
+		f.T.Fatalf("GetRoutes() error = %v", err)
+	}
+	keys := []string{}
+	for _, r := range out.Items {
+		keys = append(keys, *r.RouteKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkRoutes checks the route keys of the API of r.
+func checkRoutes(t *testing.T, f *fake.Fixture, r *resource, want ...string) {
+	t.Helper()
+	if got := routeKeys(f, r.ko.Status.APIID); !reflect.DeepEqual(got, append([]string{}, want...)) {
+		t.Errorf("routes = %v, want %v", got, want)
+	}
+}
+
+// imported replaces the spec of r with the definition, as the spec of an
+// imported API holds only its definition.
+func imported(body string) func(r *resource) {
+	return func(r *resource) { r.ko.Spec = svcapitypes.APISpec{Body: aws.String(body)} }
+}
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New:     func(*fake.Fixture) *resource { return newHTTPAPI("pets") },
+		Missing: func(r *resource) { r.ko.Status.APIID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name:      "created",
+				WantCalls: []string{"CreateApi"},
+				Check: func(t *testing.T, f *fake.Fixture, r *resource) {
+					wantARN := "arn:aws:apigateway:us-west-2::/apis/" + *r.ko.Status.APIID
+					if got := string(*r.ko.Status.ACKResourceMetadata.ARN); got != wantARN {
+						t.Errorf("ARN = %q, want %q", got, wantARN)
+					}
+					if got := aws.ToString(r.ko.Spec.RouteSelectionExpression); got != "$request.method $request.path" {
+						t.Errorf("RouteSelectionExpression = %q, want the API Gateway default", got)
+					}
+					if r.ko.Status.APIEndpoint == nil {
+						t.Errorf("APIEndpoint not set")
+					}
+					checkRoutes(t, f, r)
+				},
+			},
+			{
+				Name:      "quick create",
+				Change:    func(r *resource) { r.ko.Spec.Target = aws.String("https://example.com") },
+				WantCalls: []string{"CreateApi"},
+				Check: func(t *testing.T, f *fake.Fixture, r *resource) {
+					checkRoutes(t, f, r, "$default")
+				},
+			},
+			{
+				Name:      "imported",
+				Change:    imported(petsDefinition),
+				WantCalls: []string{"ImportApi"},
+				Check: func(t *testing.T, f *fake.Fixture, r *resource) {
+					checkRoutes(t, f, r, "GET /pets", "POST /pets")
+				},
+			},
+			{
+				Name:      "invalid definition",
+				Change:    imported(`{"openapi":"2.0"}`),
+				WantErr:   ackerr.Terminal,
+				WantCalls: []string{},
+			},
+			{
+				Name: "definition with other fields",
+				Change: func(r *resource) {
+					r.ko.Spec.Body = aws.String(petsDefinition)
+				},
+				WantErr:   errors.New("only 'FailOnWarnings' and 'Basepath' fields can be used with 'Body' field"),
+				WantCalls: []string{},
+			},
+			{
+				Name:      "missing protocol",
+				Change:    func(r *resource) { r.ko.Spec.ProtocolType = nil },
+				WantErr:   errors.New("'Name' and 'ProtocolType' are required properties if 'Body' field is not present"),
+				WantCalls: []string{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:      "description",
+				Change:    func(r *resource) { r.ko.Spec.Description = aws.String("pet store") },
+				WantCalls: []string{"UpdateApi"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.Description); got != "pet store" {
+						t.Errorf("Description = %q, want pet store", got)
+					}
+				},
+			},
+			{
+				Name: "CORS removed",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.UpdateApi(context.Background(), &svcsdk.UpdateApiInput{
+						ApiId:             r.ko.Status.APIID,
+						CorsConfiguration: &svcsdktypes.Cors{AllowOrigins: []string{"https://example.com"}},
+					}); err != nil {
+						f.T.Fatalf("UpdateApi() error = %v", err)
+					}
+				},
+				Change:    func(r *resource) { r.ko.Spec.CORSConfiguration = nil },
+				WantCalls: []string{"DeleteCorsConfiguration", "UpdateApi"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.CORSConfiguration != nil {
+						t.Errorf("CORSConfiguration = %+v, want none", r.ko.Spec.CORSConfiguration)
+					}
+				},
+			},
+			{
+				Name:      "tags only",
+				Change:    func(r *resource) { r.ko.Spec.Tags = map[string]*string{"team": aws.String("pets")} },
+				WantCalls: []string{"TagResource"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToStringMap(r.ko.Spec.Tags); !reflect.DeepEqual(got, map[string]string{"team": "pets"}) {
+						t.Errorf("Tags = %v, want map[team:pets]", got)
+					}
+				},
+			},
+			{
+				Name:      "protocol changed",
+				Change:    func(r *resource) { r.ko.Spec.ProtocolType = aws.String("WEBSOCKET") },
+				WantErr:   ackerr.Terminal,
+				WantCalls: []string{},
+			},
+		},
+	}.Run(t)
+}
+
+func TestSdkUpdate_Reimported(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	latest := f.Create(newImportedAPI(petsDefinition)).(*resource)
+	desired := newImportedAPI(petsDefinitionV2)
+	desired.ko.Status = latest.ko.Status
+	f.Client.ResetCalls()
+
+	if _, err := f.Update(desired, latest); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if calls := f.Client.Calls(); !reflect.DeepEqual(calls, []string{"ReimportApi"}) {
+		t.Errorf("calls = %v, want [ReimportApi]", calls)
+	}
+	checkRoutes(t, f, latest, "DELETE /pets/{id}")
+	out, err := f.Client.GetApi(context.Background(), &svcsdk.GetApiInput{ApiId: latest.ko.Status.APIID})
+	if err != nil {
+		t.Fatalf("GetApi() error = %v", err)
+	}
+	if got := aws.ToString(out.Version); got != "2.0" {
+		t.Errorf("Version = %q, want 2.0", got)
+	}
+}
+
+func TestSdk_BodyFrom(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+		Data:       map[string]string{"openapi.yaml": petsDefinition},
+	}
+	kc := kubefake.NewClient(cm)
+	desired := &resource{&svcapitypes.API{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+		Spec: svcapitypes.APISpec{BodyFrom: &svcapitypes.APIBodySource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "pets"},
+				Key:                  "openapi.yaml",
+			},
+		}},
+	}}
+	resolve := func() *resource {
+		t.Helper()
+		resolved, _, err := f.Manager.ResolveReferences(f.Context, kc, desired)
+		if err != nil {
+			t.Fatalf("ResolveReferences() error = %v", err)
+		}
+		if desired.ko.Spec.Body != nil {
+			t.Fatalf("ResolveReferences() set the Body of the desired resource")
+		}
+		return resolved.(*resource)
+	}
+	// checkCleared checks that the definition read from BodyFrom is not
+	// written back to the spec.
+	checkCleared := func(op string, r acktypes.AWSResource) {
+		t.Helper()
+		if body := r.(*resource).ko.Spec.Body; body != nil {
+			t.Errorf("%s: Body = %q, want it cleared", op, *body)
+		}
+	}
+
+	resolved := resolve()
+	created, err := f.Manager.Create(f.Context, resolved)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	checkCleared("Create", created)
+	checkRoutes(t, f, created.(*resource), "GET /pets", "POST /pets")
+	latest := f.ReadOne(created)
+	checkCleared("ReadOne", latest)
+	if delta := f.Descriptor.Delta(resolved, latest); delta.DifferentAt("Spec.Body") {
+		t.Errorf("delta differs at Spec.Body for an unchanged definition")
+	}
+
+	// A new definition in the ConfigMap is imported again.
+	cm.Data["openapi.yaml"] = petsDefinitionV2
+	if err := kc.Update(context.Background(), cm); err != nil {
+		t.Fatalf("Update(ConfigMap) error = %v", err)
+	}
+	resolved = resolve()
+	resolved.ko.Status = latest.(*resource).ko.Status
+	if delta := f.Descriptor.Delta(resolved, latest); !delta.DifferentAt("Spec.Body") {
+		t.Fatalf("delta does not differ at Spec.Body for a new definition")
+	}
+	updated, err := f.Update(resolved, latest)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	checkCleared("Update", updated)
+	checkRoutes(t, f, updated.(*resource), "DELETE /pets/{id}")
+}
+
+func TestSdkFind_AdoptedByName(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	existing := f.Create(newHTTPAPI("pets")).(*resource)
+	websocketID := f.CreateAPI(svcsdktypes.ProtocolTypeWebsocket)
+	f.Create(newHTTPAPI("orders"))
+	f.Create(newHTTPAPI("orders"))
+	// List the APIs over several pages.
+	f.Client.PageSize = 1
+	byFields := func(name, protocolType string) *resource {
+		return f.Adopt(&resource{&svcapitypes.API{}}, "adopt", map[string]string{
+			"apiID": "", "name": name, "protocolType": protocolType,
+		}).(*resource)
+	}
+
+	tests := []struct {
+		name    string
+		r       *resource
+		wantID  string
+		wantErr error
+	}{
+		{name: "name in the adoption fields", r: byFields("pets", "HTTP"), wantID: *existing.ko.Status.APIID},
+		{name: "protocol type", r: byFields("pets", "WEBSOCKET"), wantID: websocketID},
+		{
+			name:   "name in the spec",
+			r:      f.Adopt(newHTTPAPI("pets"), "adopt-or-create", nil).(*resource),
+			wantID: *existing.ko.Status.APIID,
+		},
+		{name: "ambiguous name", r: byFields("orders", "HTTP"), wantErr: ackerr.Terminal},
+		{name: "missing name", r: byFields("cats", "HTTP"), wantErr: ackerr.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, err := f.Manager.ReadOne(f.Context, tt.r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadOne() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := aws.ToString(latest.(*resource).ko.Status.APIID); got != tt.wantID {
+				t.Errorf("APIID = %q, want %q", got, tt.wantID)
+			}
+		})
+	}
+}
+
+// exportedCondition returns the Exported condition of the API, or nil.
+func exportedCondition(ko *svcapitypes.API) *ackv1alpha1.Condition {
+	for _, cond := range ko.Status.Conditions {
+		if cond.Type == ConditionTypeExported {
+			return cond
+		}
+	}
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	kc := ctrlfake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := kubeclient.IntoContext(context.Background(), kc)
+	cmKey := types.NamespacedName{Namespace: "default", Name: "pets-openapi"}
+
+	rm := fake.NewFixture(t, newResourceManagerFactory()).Manager.(*resourceManager)
+	r := newImportedAPI(petsDefinition)
+	r.ko.Spec.Export = &svcapitypes.APIExport{ConfigMapName: aws.String("pets-openapi")}
+	created, err := rm.sdkCreate(ctx, r)
+	if err != nil {
+		t.Fatalf("sdkCreate() error = %v", err)
+	}
+
+	// The definition is exported when the API is created.
+	cm := &corev1.ConfigMap{}
+	if err := kc.Get(ctx, cmKey, cm); err != nil {
+		t.Fatalf("Get(ConfigMap) error = %v", err)
+	}
+	definition, ok := cm.Data[exportKey(defaultExportOutputType)]
+	if !ok {
+		t.Fatalf("ConfigMap data = %v, want the exported definition", cm.Data)
+	}
+	if got, want := aws.ToString(created.ko.Status.ExportHash), bodyHash(definition); got != want {
+		t.Errorf("ExportHash = %q, want %q", got, want)
+	}
+
+	// Reading an API whose export is up to date neither exports it nor
+	// requests an update.
+	latest, err := rm.sdkFind(ctx, created)
+	if err != nil {
+		t.Fatalf("sdkFind() error = %v", err)
+	}
+	if cond := exportedCondition(latest.ko); cond == nil || cond.Status != corev1.ConditionTrue {
+		t.Fatalf("Exported condition = %v, want True", cond)
+	}
+	if delta := newResourceDelta(created, latest); delta.DifferentAt("Spec.Export") {
+		t.Errorf("delta differs at Spec.Export for an up to date export")
+	}
+
+	// A new route of the API and the deletion of the ConfigMap both mark the
+	// export out of date.
+	for _, change := range []struct {
+		name  string
+		apply func(t *testing.T)
+	}{
+		{
+			name: "route added",
+			apply: func(t *testing.T) {
+				route := &svcapitypes.Route{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "get-pets"},
+					Spec: svcapitypes.RouteSpec{
+						APIRef:   &ackv1alpha1.AWSResourceReferenceWrapper{From: &ackv1alpha1.AWSResourceReference{Name: aws.String("pets")}},
+						RouteKey: aws.String("GET /pets"),
+					},
+				}
+				if err := kc.Create(ctx, route); err != nil {
+					t.Fatalf("Create(Route) error = %v", err)
+				}
+			},
+		},
+		{
+			name: "ConfigMap deleted",
+			apply: func(t *testing.T) {
+				if err := kc.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: cmKey.Namespace, Name: cmKey.Name}}); err != nil {
+					t.Fatalf("Delete(ConfigMap) error = %v", err)
+				}
+			},
+		},
+	} {
+		t.Run(change.name, func(t *testing.T) {
+			change.apply(t)
+			latest, err := rm.sdkFind(ctx, created)
+			if err != nil {
+				t.Fatalf("sdkFind() error = %v", err)
+			}
+			if cond := exportedCondition(latest.ko); cond == nil || cond.Status != corev1.ConditionFalse {
+				t.Fatalf("Exported condition = %v, want False", cond)
+			}
+			delta := newResourceDelta(created, latest)
+			if !delta.DifferentAt("Spec.Export") {
+				t.Fatalf("delta does not differ at Spec.Export for an outdated export")
+			}
+			updated, err := rm.sdkUpdate(ctx, created, latest, delta)
+			if err != nil {
+				t.Fatalf("sdkUpdate() error = %v", err)
+			}
+			if cond := exportedCondition(updated.ko); cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("Exported condition after update = %v, want True", cond)
+			}
+			if err := kc.Get(ctx, cmKey, &corev1.ConfigMap{}); err != nil {
+				t.Fatalf("Get(ConfigMap) error = %v", err)
+			}
+			created = updated
+		})
+	}
+}
+
+func TestExport_Failed(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		update func(ko *svcapitypes.API)
+	}{
+		{
+			name: "no Kubernetes client",
+			ctx:  context.Background(),
+		},
+		{
+			name: "WebSocket API",
+			ctx:  kubeclient.IntoContext(context.Background(), ctrlfake.NewClientBuilder().Build()),
+			update: func(ko *svcapitypes.API) {
+				ko.Spec.ProtocolType = aws.String("WEBSOCKET")
+				ko.Spec.RouteSelectionExpression = aws.String("$request.body.action")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := fake.NewFixture(t, newResourceManagerFactory()).Manager.(*resourceManager)
+			r := newHTTPAPI("pets")
+			r.ko.Spec.Export = &svcapitypes.APIExport{ConfigMapName: aws.String("pets-openapi")}
+			if tt.update != nil {
+				tt.update(r.ko)
+			}
+			// A failed export does not fail the creation of the API.
+			created, err := rm.sdkCreate(tt.ctx, r)
+			if err != nil {
+				t.Fatalf("sdkCreate() error = %v", err)
+			}
+			cond := exportedCondition(created.ko)
+			if cond == nil || cond.Status != corev1.ConditionFalse || aws.ToString(cond.Reason) != "ExportFailed" {
+				t.Fatalf("Exported condition = %v, want False with reason ExportFailed", cond)
+			}
+			if created.ko.Status.ExportHash != nil {
+				t.Errorf("ExportHash = %q, want unset", *created.ko.Status.ExportHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// ValidateAPI checks the spec of an API the way the resource manager does
+// before calling ImportApi or CreateApi, so that impossible specs can be
+// rejected at admission time. The definition of an import that reads its
+// Body from BodyFrom is only checked during reconciliation.
+func ValidateAPI(api *v1alpha1.API) error {
+	var err error
+	switch {
+	case api.Spec.BodyFrom != nil && api.Spec.Body != nil:
+		err = ackerr.ResourceReferenceAndIDNotSupportedFor("Body", "BodyFrom")
+	case api.Spec.BodyFrom != nil:
+		err = validateImportOnlyFields(api.Spec)
+	case importFieldsPresent(api):
+		err = validateImportApiInputFields(api)
+	default:
+		err = validateCreateApiInputFields(api)
+	}
+	if err != nil {
+		return err
+	}
+	if api.Spec.Export != nil && api.Spec.ProtocolType != nil &&
+		*api.Spec.ProtocolType == string(svcsdktypes.ProtocolTypeWebsocket) {
+		return fmt.Errorf("Export is supported only for HTTP APIs")
+	}
+	return nil
+}
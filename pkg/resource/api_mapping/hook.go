@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api_mapping
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// setResourceARN sets the ARN of the API mapping from its domain name and ID.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.APIMapping) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/domainnames/%s/apimappings/%s",
+		ko.Spec.DomainName, ko.Status.APIMappingID,
+	)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef and domainRef of the API mapping in its ReferencesResolved condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.APIMapping,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package api_mapping
+
+import (
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			f.CreateDomainName("api.example.com")
+			apiID := f.CreateAPI(svcsdktypes.ProtocolTypeHttp)
+			f.CreateStage(apiID, "prod")
+			return &resource{&svcapitypes.APIMapping{Spec: svcapitypes.APIMappingSpec{
+				APIID:         aws.String(apiID),
+				APIMappingKey: aws.String("v1"),
+				DomainName:    aws.String("api.example.com"),
+				Stage:         aws.String("prod"),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.APIMappingID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.APIMappingKey); got != "v1" {
+						t.Errorf("APIMappingKey = %q, want v1", got)
+					}
+				},
+			},
+			{
+				Name:    "key taken",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "missing stage",
+				Change:  func(r *resource) { r.ko.Spec.Stage = aws.String("dev") },
+				WantErr: ackerr.Terminal,
+			},
+			{
+				Name:    "missing domain name",
+				Change:  func(r *resource) { r.ko.Spec.DomainName = aws.String("www.example.com") },
+				WantErr: &svcsdktypes.NotFoundException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "mapping key",
+				Change: func(r *resource) { r.ko.Spec.APIMappingKey = aws.String("v2") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.APIMappingKey); got != "v2" {
+						t.Errorf("APIMappingKey = %q, want v2", got)
+					}
+				},
+			},
+			{
+				Name: "domain name changed",
+				Change: func(r *resource) {
+					r.ko.Spec.DomainName = aws.String("www.example.com")
+					r.ko.Spec.APIMappingKey = aws.String("v2")
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.APIMappingKey); got != "v1" {
+						t.Errorf("APIMappingKey = %q, want v1", got)
+					}
+				},
+			},
+		},
+	}.Run(t)
+}
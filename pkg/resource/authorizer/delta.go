@@ -41,6 +41,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.APIID, b.ko.Spec.APIID) {
 		delta.Add("Spec.APIID", a.ko.Spec.APIID, b.ko.Spec.APIID)
@@ -94,6 +95,9 @@ func newResourceDelta(
 			delta.Add("Spec.EnableSimpleResponses", a.ko.Spec.EnableSimpleResponses, b.ko.Spec.EnableSimpleResponses)
 		}
 	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.FunctionRef, b.ko.Spec.FunctionRef) {
+		delta.Add("Spec.FunctionRef", a.ko.Spec.FunctionRef, b.ko.Spec.FunctionRef)
+	}
 	if len(a.ko.Spec.IdentitySource) != len(b.ko.Spec.IdentitySource) {
 		delta.Add("Spec.IdentitySource", a.ko.Spec.IdentitySource, b.ko.Spec.IdentitySource)
 	} else if len(a.ko.Spec.IdentitySource) > 0 {
@@ -126,6 +130,13 @@ func newResourceDelta(
 			}
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission) {
+		delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+	} else if a.ko.Spec.ManageInvokePermission != nil && b.ko.Spec.ManageInvokePermission != nil {
+		if *a.ko.Spec.ManageInvokePermission != *b.ko.Spec.ManageInvokePermission {
+			delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 	} else if a.ko.Spec.Name != nil && b.ko.Spec.Name != nil {
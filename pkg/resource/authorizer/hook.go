@@ -0,0 +1,171 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// setResourceARN sets the ARN of the authorizer from its API and authorizer IDs.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.Authorizer) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/authorizers/%s",
+		ko.Spec.APIID, ko.Status.AuthorizerID,
+	)
+}
+
+// resolveReferenceForAuthorizerURI reads the Lambda Function referenced from
+// FunctionRef and sets the URI that invokes it as the AuthorizerURI. Returns a
+// boolean indicating whether the resource contains references, or an error.
+// AuthorizerURI and FunctionRef cannot be both set.
+func (rm *resourceManager) resolveReferenceForAuthorizerURI(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Authorizer,
+) (hasReferences bool, err error) {
+	if ko.Spec.FunctionRef != nil && ko.Spec.AuthorizerURI != nil {
+		return true, ackerr.ResourceReferenceAndIDNotSupportedFor("AuthorizerURI", "FunctionRef")
+	}
+	if ko.Spec.FunctionRef == nil || ko.Spec.FunctionRef.From == nil {
+		return false, nil
+	}
+	hasReferences = true
+	arr := ko.Spec.FunctionRef.From
+	if arr.Name == nil || *arr.Name == "" {
+		return hasReferences, fmt.Errorf("provided resource reference is nil or empty: FunctionRef")
+	}
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		ko.ObjectMeta.GetNamespace(),
+		arr.Namespace,
+		*arr.Name,
+	)
+	if err != nil {
+		return hasReferences, err
+	}
+	functionARN, err := lambda.ReferencedARN(ctx, apiReader, lambda.FunctionGVK, *arr.Name, namespace)
+	if err != nil {
+		return hasReferences, err
+	}
+	uri, err := lambda.InvocationURI(functionARN)
+	if err != nil {
+		return hasReferences, ackerr.NewTerminalError(err)
+	}
+	ko.Spec.AuthorizerURI = &uri
+	return hasReferences, nil
+}
+
+// validateIdentitySource checks the identity sources of the authorizer before
+// it is created or they are updated. API Gateway would reject them as well, so
+// the error is terminal.
+func validateIdentitySource(ko *svcapitypes.Authorizer) error {
+	if errs := ValidateIdentitySource(field.NewPath("spec"), &ko.Spec); len(errs) > 0 {
+		return ackerr.NewTerminalError(errs.ToAggregate())
+	}
+	return nil
+}
+
+// invokePermission returns the invoke permission managed for the authorizer.
+func invokePermission(ko *svcapitypes.Authorizer) lambda.InvokePermission {
+	return lambda.InvokePermission{
+		Kind:          "authorizer",
+		Manage:        ko.Spec.ManageInvokePermission,
+		Type:          ko.Spec.AuthorizerType,
+		SupportedType: "REQUEST",
+		URIField:      "AuthorizerURI",
+		URI:           ko.Spec.AuthorizerURI,
+		StatementID:   "apigatewayv2-authorizer-" + aws.ToString(ko.Status.AuthorizerID),
+	}
+}
+
+// customPreCompare reports a difference at Spec.ManageInvokePermission when
+// the invoke permission recorded in the status does not match the desired
+// one, so that the permission is granted, moved or removed on update.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !invokePermission(a.ko).InSync(b.ko.Status.InvokePermissionFunctionARN) {
+		delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+	}
+}
+
+// syncInvokePermission grants API Gateway the permission to invoke the
+// Lambda function of the authorizer, scoped to the authorizer, and removes
+// the permission previously granted on another function.
+func (rm *resourceManager) syncInvokePermission(
+	ctx context.Context,
+	ko *svcapitypes.Authorizer,
+) (err error) {
+	p := invokePermission(ko)
+	p.SourceARN = lambda.ExecuteAPIARN(
+		string(rm.awsPartition), string(rm.awsRegion), string(rm.awsAccountID),
+		aws.ToString(ko.Spec.APIID), "authorizers/"+aws.ToString(ko.Status.AuthorizerID),
+	)
+	ko.Status.InvokePermissionFunctionARN, err = lambda.SyncInvokePermission(
+		ctx, rm.clientcfg, rm.metrics, p, ko.Status.InvokePermissionFunctionARN,
+	)
+	return err
+}
+
+// removeInvokePermission removes the invoke permission recorded in the
+// status of the authorizer, if any.
+func (rm *resourceManager) removeInvokePermission(
+	ctx context.Context,
+	ko *svcapitypes.Authorizer,
+) (err error) {
+	ko.Status.InvokePermissionFunctionARN, err = lambda.RemoveInvokePermission(
+		ctx, rm.clientcfg, rm.metrics, invokePermission(ko), ko.Status.InvokePermissionFunctionARN,
+	)
+	return err
+}
+
+// checkDependents blocks the deletion of the authorizer while Routes refer to
+// it with their authorizerRef.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef and functionRef of the authorizer in its ReferencesResolved
+// condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Authorizer,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -0,0 +1,137 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package authorizer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+var (
+	// httpIdentitySourceRegex matches the identity sources of HTTP API
+	// authorizers, e.g. "$request.header.Authorization".
+	httpIdentitySourceRegex = regexp.MustCompile(`^\$(request\.header|request\.querystring|context|stageVariables)\.(.*)$`)
+	// webSocketIdentitySourceRegex matches the identity sources of WebSocket
+	// API authorizers, e.g. "route.request.header.Auth".
+	webSocketIdentitySourceRegex = regexp.MustCompile(`^(route\.request\.header|route\.request\.querystring|context|stageVariables)\.(.*)$`)
+
+	// identitySourceNameRegexes validate the part of an identity source that
+	// follows its location.
+	identitySourceNameRegexes = map[string]*regexp.Regexp{
+		"request.header":            regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$"),
+		"request.querystring":       regexp.MustCompile(`^[^\s&=#]+$`),
+		"context":                   regexp.MustCompile(`^[A-Za-z0-9_.-]+$`),
+		"stageVariables":            regexp.MustCompile(`^[A-Za-z0-9_]+$`),
+		"route.request.header":      regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$"),
+		"route.request.querystring": regexp.MustCompile(`^[^\s&=#]+$`),
+	}
+)
+
+// ValidateIdentitySource checks the identity sources of an authorizer
+// against its type and payload format version, the way API Gateway does when
+// the authorizer is created:
+//
+//   - JWT authorizers take a single "$request.header.X" or
+//     "$request.querystring.X" source.
+//   - REQUEST authorizers of HTTP APIs take "$request.header.X",
+//     "$request.querystring.X", "$context.X" and "$stageVariables.X" sources
+//     and require a payload format version.
+//   - REQUEST authorizers of WebSocket APIs take the same sources without the
+//     '$' prefix, with "route.request." in place of "request.", and have no
+//     payload format version.
+//
+// Authorizer caching needs at least one identity source. Errors are reported
+// under the given spec path.
+func ValidateIdentitySource(specPath *field.Path, spec *svcapitypes.AuthorizerSpec) field.ErrorList {
+	fldPath := specPath.Child("identitySource")
+	var errs field.ErrorList
+	var httpSources, webSocketSources int
+	seen := map[string]bool{}
+	for i, s := range spec.IdentitySource {
+		idxPath := fldPath.Index(i)
+		if s == nil || *s == "" {
+			errs = append(errs, field.Required(idxPath, "identity source must not be empty"))
+			continue
+		}
+		if seen[*s] {
+			errs = append(errs, field.Duplicate(idxPath, *s))
+			continue
+		}
+		seen[*s] = true
+		location, name, http, ok := parseIdentitySource(*s)
+		switch {
+		case !ok:
+			errs = append(errs, field.Invalid(idxPath, *s,
+				`identity source must be one of "$request.header.X", "$request.querystring.X", "$context.X" and "$stageVariables.X"`))
+			continue
+		case !identitySourceNameRegexes[location].MatchString(name):
+			errs = append(errs, field.Invalid(idxPath, *s, fmt.Sprintf("invalid %s name %q", location, name)))
+			continue
+		}
+		if !http {
+			webSocketSources++
+			continue
+		}
+		httpSources++
+		if aws.ToString(spec.AuthorizerType) == "JWT" && location != "request.header" && location != "request.querystring" {
+			errs = append(errs, field.Invalid(idxPath, *s,
+				"JWT authorizers read the token from a request header or query string parameter"))
+		}
+	}
+
+	switch aws.ToString(spec.AuthorizerType) {
+	case "JWT":
+		if len(spec.IdentitySource) != 1 {
+			errs = append(errs, field.Invalid(fldPath, len(spec.IdentitySource), "JWT authorizers require exactly one identity source"))
+		}
+		if webSocketSources > 0 {
+			errs = append(errs, field.Forbidden(fldPath, "JWT authorizers are supported only for HTTP APIs, whose identity sources start with '$'"))
+		}
+	case "REQUEST":
+		hasVersion := spec.AuthorizerPayloadFormatVersion != nil
+		switch {
+		case httpSources > 0 && webSocketSources > 0:
+			errs = append(errs, field.Forbidden(fldPath,
+				"identity sources of HTTP APIs, which start with '$', cannot be mixed with those of WebSocket APIs"))
+		case httpSources > 0 && !hasVersion:
+			errs = append(errs, field.Required(specPath.Child("authorizerPayloadFormatVersion"),
+				"REQUEST authorizers of HTTP APIs require a payload format version"))
+		case webSocketSources > 0 && hasVersion:
+			errs = append(errs, field.Forbidden(specPath.Child("authorizerPayloadFormatVersion"),
+				"identity sources without the '$' prefix are those of WebSocket APIs, which take no payload format version"))
+		}
+		if ttl := spec.AuthorizerResultTTLInSeconds; ttl != nil && *ttl > 0 && len(spec.IdentitySource) == 0 {
+			errs = append(errs, field.Required(fldPath, "authorizer caching requires at least one identity source"))
+		}
+	}
+	return errs
+}
+
+// parseIdentitySource splits an identity source into its location, e.g.
+// "request.header", and the name that follows it. http is true for the
+// '$'-prefixed sources of HTTP APIs.
+func parseIdentitySource(s string) (location string, name string, http bool, ok bool) {
+	if m := httpIdentitySourceRegex.FindStringSubmatch(s); m != nil {
+		return m[1], m[2], true, true
+	}
+	if m := webSocketIdentitySourceRegex.FindStringSubmatch(s); m != nil {
+		return m[1], m[2], false, true
+	}
+	return "", "", false, false
+}
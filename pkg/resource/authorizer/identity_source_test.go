@@ -0,0 +1,150 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package authorizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+func TestValidateIdentitySource(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorizerType string
+		version        string
+		ttl            int64
+		sources        []string
+		want           []string
+	}{
+		{name: "JWT header", authorizerType: "JWT", sources: []string{"$request.header.Authorization"}},
+		{name: "JWT query string", authorizerType: "JWT", sources: []string{"$request.querystring.token"}},
+		{
+			name:           "JWT without a source",
+			authorizerType: "JWT",
+			want:           []string{"spec.identitySource: Invalid value"},
+		},
+		{
+			name:           "JWT with two sources",
+			authorizerType: "JWT",
+			sources:        []string{"$request.header.Authorization", "$request.querystring.token"},
+			want:           []string{"spec.identitySource: Invalid value"},
+		},
+		{
+			name:           "JWT stage variable",
+			authorizerType: "JWT",
+			sources:        []string{"$stageVariables.token"},
+			want:           []string{"spec.identitySource[0]: Invalid value"},
+		},
+		{
+			name:           "REQUEST of an HTTP API",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			ttl:            300,
+			sources: []string{
+				"$request.header.X-Api-Key", "$request.querystring.tenant",
+				"$context.identity.sourceIp", "$stageVariables.stage_name",
+			},
+		},
+		{name: "REQUEST without a source", authorizerType: "REQUEST", version: "2.0"},
+		{
+			name:           "REQUEST of a WebSocket API",
+			authorizerType: "REQUEST",
+			sources:        []string{"route.request.header.Auth", "route.request.querystring.Name", "context.connectionId"},
+		},
+		{
+			name:           "REQUEST of an HTTP API without a payload format version",
+			authorizerType: "REQUEST",
+			sources:        []string{"$request.header.Authorization"},
+			want:           []string{"spec.authorizerPayloadFormatVersion: Required value"},
+		},
+		{
+			name:           "REQUEST of a WebSocket API with a payload format version",
+			authorizerType: "REQUEST",
+			version:        "1.0",
+			sources:        []string{"route.request.header.Auth"},
+			want:           []string{"spec.authorizerPayloadFormatVersion: Forbidden"},
+		},
+		{
+			name:           "REQUEST mixing HTTP and WebSocket sources",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			sources:        []string{"$request.header.Authorization", "route.request.header.Auth"},
+			want:           []string{"spec.identitySource: Forbidden"},
+		},
+		{
+			name:           "REQUEST with caching and no source",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			ttl:            300,
+			want:           []string{"spec.identitySource: Required value"},
+		},
+		{
+			name:           "unknown location",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			sources:        []string{"$request.body.token"},
+			want:           []string{"spec.identitySource[0]: Invalid value"},
+		},
+		{
+			name:           "invalid header name",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			sources:        []string{"$request.header.X Api Key"},
+			want:           []string{"spec.identitySource[0]: Invalid value"},
+		},
+		{
+			name:           "empty stage variable name",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			sources:        []string{"$stageVariables."},
+			want:           []string{"spec.identitySource[0]: Invalid value"},
+		},
+		{
+			name:           "duplicate",
+			authorizerType: "REQUEST",
+			version:        "2.0",
+			sources:        []string{"$request.header.Authorization", "$request.header.Authorization"},
+			want:           []string{"spec.identitySource[1]: Duplicate value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &svcapitypes.AuthorizerSpec{AuthorizerType: aws.String(tt.authorizerType)}
+			if tt.version != "" {
+				spec.AuthorizerPayloadFormatVersion = aws.String(tt.version)
+			}
+			if tt.ttl != 0 {
+				spec.AuthorizerResultTTLInSeconds = aws.Int64(tt.ttl)
+			}
+			for _, s := range tt.sources {
+				spec.IdentitySource = append(spec.IdentitySource, aws.String(s))
+			}
+
+			errs := ValidateIdentitySource(field.NewPath("spec"), spec)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ValidateIdentitySource() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want prefix %q", i, errs[i].Error(), want)
+				}
+			}
+		})
+	}
+}
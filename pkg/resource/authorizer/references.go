@@ -58,6 +58,9 @@ func (rm *resourceManager) ResolveReferences(
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
 	ko := rm.concreteResource(res).ko
+	if err := rm.setReferencesResolved(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, true, err
+	}
 
 	resourceHasReferences := false
 	err := validateReferenceFields(ko)
@@ -67,6 +70,13 @@ func (rm *resourceManager) ResolveReferences(
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	// resolve the Lambda Function reference into the AuthorizerURI
+	if fieldHasReferences, err := rm.resolveReferenceForAuthorizerURI(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	return &resource{ko}, resourceHasReferences, err
 }
 
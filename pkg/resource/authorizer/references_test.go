@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+)
+
+func TestResolveReferences_FunctionRef(t *testing.T) {
+	const functionARN = "arn:aws:lambda:us-west-2:123456789012:function:auth"
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	synced := []*ackv1alpha1.Condition{{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionTrue,
+	}}
+	function := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"ackResourceMetadata": map[string]interface{}{"arn": functionARN},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "ACK.ResourceSynced", "status": "True"},
+			},
+		},
+	}}
+	function.SetGroupVersionKind(lambda.FunctionGVK)
+	function.SetNamespace("default")
+	function.SetName("auth")
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Status:     svcapitypes.APIStatus{APIID: aws.String("a1b2c3"), Conditions: synced},
+		},
+		function,
+	).Build()
+	ref := func(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+		return &ackv1alpha1.AWSResourceReferenceWrapper{From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)}}
+	}
+	desired := &resource{&svcapitypes.Authorizer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "auth"},
+		Spec: svcapitypes.AuthorizerSpec{
+			APIRef:                         ref("pets"),
+			FunctionRef:                    ref("auth"),
+			AuthorizerType:                 aws.String("REQUEST"),
+			AuthorizerPayloadFormatVersion: aws.String("2.0"),
+			IdentitySource:                 []*string{aws.String("$request.header.Authorization")},
+		},
+	}}
+
+	rm := &resourceManager{}
+	resolved, hasReferences, err := rm.ResolveReferences(context.Background(), apiReader, desired)
+	if !hasReferences || err != nil {
+		t.Fatalf("ResolveReferences() = %v, %v, want resolved references", hasReferences, err)
+	}
+	ko := resolved.(*resource).ko
+	want := "arn:aws:apigateway:us-west-2:lambda:path/2015-03-31/functions/" + functionARN + "/invocations"
+	if got := aws.ToString(ko.Spec.AuthorizerURI); got != want {
+		t.Errorf("AuthorizerURI = %q, want %q", got, want)
+	}
+
+	both := &resource{desired.ko.DeepCopy()}
+	both.ko.Spec.AuthorizerURI = aws.String(want)
+	both.ko.Spec.APIID = nil
+	if _, _, err := rm.ResolveReferences(context.Background(), apiReader, both); err == nil {
+		t.Errorf("ResolveReferences() with AuthorizerURI and FunctionRef succeeded, want an error")
+	}
+}
@@ -156,6 +156,7 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
 	return &resource{ko}, nil
 }
 
@@ -198,6 +199,9 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err := validateIdentitySource(desired.ko); err != nil {
+		return nil, err
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
@@ -279,6 +283,10 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
+	if err := rm.syncInvokePermission(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
 	return &resource{ko}, nil
 }
 
@@ -352,6 +360,23 @@ func (rm *resourceManager) sdkUpdate(
 	defer func() {
 		exit(err)
 	}()
+	if immutableFieldChanges := rm.getImmutableFieldChanges(delta); len(immutableFieldChanges) > 0 {
+		msg := fmt.Sprintf("Immutable Spec fields have been modified: %s", strings.Join(immutableFieldChanges, ","))
+		return nil, ackerr.NewTerminalError(errors.New(msg))
+	}
+	if delta.DifferentAt("Spec.IdentitySource") ||
+		delta.DifferentAt("Spec.AuthorizerType") ||
+		delta.DifferentAt("Spec.AuthorizerPayloadFormatVersion") ||
+		delta.DifferentAt("Spec.AuthorizerResultTTLInSeconds") {
+		if err := validateIdentitySource(desired.ko); err != nil {
+			return nil, err
+		}
+	}
+	if delta.DifferentAt("Spec.ManageInvokePermission") {
+		if err := rm.syncInvokePermission(ctx, desired.ko); err != nil {
+			return nil, err
+		}
+	}
 	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
 	if err != nil {
 		return nil, err
@@ -507,6 +532,12 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err := rm.checkDependents(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := rm.removeInvokePermission(ctx, r.ko); err != nil {
+		return nil, err
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -640,3 +671,16 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 	// No terminal_errors specified for this resource in generator config
 	return false
 }
+
+// getImmutableFieldChanges returns the immutable fields that differ between
+// the desired and latest state of the resource.
+func (rm *resourceManager) getImmutableFieldChanges(
+	delta *ackcompare.Delta,
+) []string {
+	var fields []string
+	if delta.DifferentAt("Spec.APIID") {
+		fields = append(fields, "APIID")
+	}
+
+	return fields
+}
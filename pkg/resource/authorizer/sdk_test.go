@@ -0,0 +1,125 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package authorizer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/smithy-go"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			return &resource{&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+				APIID:          aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeHttp)),
+				Name:           aws.String("jwt"),
+				AuthorizerType: aws.String("JWT"),
+				IdentitySource: []*string{aws.String("$request.header.Authorization")},
+				JWTConfiguration: &svcapitypes.JWTConfiguration{
+					Audience: []*string{aws.String("pets")},
+					Issuer:   aws.String("https://issuer.example.com"),
+				},
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.AuthorizerID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if aws.ToString(r.ko.Spec.AuthorizerType) != "JWT" ||
+						aws.ToString(r.ko.Spec.JWTConfiguration.Issuer) != "https://issuer.example.com" {
+						t.Errorf("Spec = %+v, want the JWT authorizer", r.ko.Spec)
+					}
+					want := []*string{aws.String("$request.header.Authorization")}
+					if !reflect.DeepEqual(r.ko.Spec.IdentitySource, want) {
+						t.Errorf("IdentitySource = %v, want %v", aws.ToStringSlice(r.ko.Spec.IdentitySource), aws.ToStringSlice(want))
+					}
+				},
+			},
+			{
+				Name:    "missing API",
+				Change:  func(r *resource) { r.ko.Spec.APIID = aws.String("missing") },
+				WantErr: &svcsdktypes.NotFoundException{},
+			},
+			{
+				Name:    "missing name",
+				Change:  func(r *resource) { r.ko.Spec.Name = nil },
+				WantErr: smithy.InvalidParamsError{},
+			},
+			{
+				Name: "token read from a stage variable",
+				Change: func(r *resource) {
+					r.ko.Spec.IdentitySource = []*string{aws.String("$stageVariables.token")}
+				},
+				WantErr: ackerr.Terminal,
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "name",
+				Change: func(r *resource) { r.ko.Spec.Name = aws.String("cognito") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.Name); got != "cognito" {
+						t.Errorf("Name = %q, want cognito", got)
+					}
+				},
+			},
+			{
+				Name:    "API changed",
+				Change:  func(r *resource) { r.ko.Spec.APIID = aws.String("other") },
+				WantErr: ackerr.Terminal,
+			},
+			{
+				Name: "second identity source",
+				Change: func(r *resource) {
+					r.ko.Spec.IdentitySource = append(r.ko.Spec.IdentitySource, aws.String("$request.querystring.token"))
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := len(r.ko.Spec.IdentitySource); got != 1 {
+						t.Errorf("len(IdentitySource) = %d, want 1", got)
+					}
+				},
+			},
+		},
+		Delete: []fake.Case[*resource]{
+			{
+				Name: "used by a route",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.CreateRoute(context.Background(), &svcsdk.CreateRouteInput{
+						ApiId:             r.ko.Spec.APIID,
+						RouteKey:          aws.String("GET /pets"),
+						AuthorizationType: svcsdktypes.AuthorizationTypeJwt,
+						AuthorizerId:      r.ko.Status.AuthorizerID,
+					}); err != nil {
+						f.T.Fatalf("CreateRoute() error = %v", err)
+					}
+				},
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package deployment
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// setResourceARN sets the ARN of the deployment from its API and deployment IDs.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.Deployment) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/deployments/%s",
+		ko.Spec.APIID, ko.Status.DeploymentID,
+	)
+}
+
+// checkDependents blocks the deletion of the deployment while Stages refer to
+// it with their deploymentRef.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef of the deployment in its ReferencesResolved condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Deployment,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+// stageDeploymentID returns the ID of the deployment of the "prod" stage of
+// the API of r.
+func stageDeploymentID(f *fake.Fixture, r *resource) string {
+	f.T.Helper()
+	out, err := f.Client.GetStage(context.Background(), &svcsdk.GetStageInput{
+		ApiId:     r.ko.Spec.APIID,
+		StageName: aws.String("prod"),
+	})
+	if err != nil {
+		f.T.Fatalf("GetStage() error = %v", err)
+	}
+	return aws.ToString(out.DeploymentId)
+}
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			apiID := f.CreateAPI(svcsdktypes.ProtocolTypeHttp)
+			f.CreateStage(apiID, "prod")
+			return &resource{&svcapitypes.Deployment{Spec: svcapitypes.DeploymentSpec{
+				APIID:       aws.String(apiID),
+				Description: aws.String("first"),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.DeploymentID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, f *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Status.DeploymentStatus); got != "DEPLOYED" {
+						t.Errorf("DeploymentStatus = %q, want DEPLOYED", got)
+					}
+					if got := stageDeploymentID(f, r); got != "" {
+						t.Errorf("stage deployment = %q, want none", got)
+					}
+				},
+			},
+			{
+				Name:   "deployed to a stage",
+				Change: func(r *resource) { r.ko.Spec.StageName = aws.String("prod") },
+				Check: func(t *testing.T, f *fake.Fixture, r *resource) {
+					if got := stageDeploymentID(f, r); got != *r.ko.Status.DeploymentID {
+						t.Errorf("stage deployment = %q, want %q", got, *r.ko.Status.DeploymentID)
+					}
+				},
+			},
+			{
+				Name:    "missing stage",
+				Change:  func(r *resource) { r.ko.Spec.StageName = aws.String("dev") },
+				WantErr: &svcsdktypes.NotFoundException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "description",
+				Change: func(r *resource) { r.ko.Spec.Description = aws.String("second") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.Description); got != "second" {
+						t.Errorf("Description = %q, want second", got)
+					}
+				},
+			},
+			{
+				Name:    "API changed",
+				Change:  func(r *resource) { r.ko.Spec.APIID = aws.String("other") },
+				WantErr: ackerr.Terminal,
+			},
+		},
+		Delete: []fake.Case[*resource]{
+			{
+				Name: "used by a stage",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.UpdateStage(context.Background(), &svcsdk.UpdateStageInput{
+						ApiId:        r.ko.Spec.APIID,
+						StageName:    aws.String("prod"),
+						DeploymentId: r.ko.Status.DeploymentID,
+					}); err != nil {
+						f.T.Fatalf("UpdateStage() error = %v", err)
+					}
+				},
+				WantErr: &svcsdktypes.BadRequestException{},
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package domain_name
+
+import (
+	"reflect"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+const certificateARN = "arn:aws:acm:us-west-2:123456789012:certificate/pets"
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(*fake.Fixture) *resource {
+			return &resource{&svcapitypes.DomainName{Spec: svcapitypes.DomainNameSpec{
+				DomainName: aws.String("api.example.com"),
+				DomainNameConfigurations: []*svcapitypes.DomainNameConfiguration{{
+					CertificateARN: aws.String(certificateARN),
+					EndpointType:   aws.String("REGIONAL"),
+					SecurityPolicy: aws.String("TLS_1_2"),
+				}},
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Spec.DomainName = aws.String("www.example.com") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Status.APIMappingSelectionExpression); got != "$request.basepath" {
+						t.Errorf("APIMappingSelectionExpression = %q, want $request.basepath", got)
+					}
+					wantARN := "arn:aws:apigateway:us-west-2::/domainnames/api.example.com"
+					if got := string(*r.ko.Status.ACKResourceMetadata.ARN); got != wantARN {
+						t.Errorf("ARN = %q, want %q", got, wantARN)
+					}
+					configs := r.ko.Spec.DomainNameConfigurations
+					if len(configs) != 1 || aws.ToString(configs[0].CertificateARN) != certificateARN {
+						t.Errorf("DomainNameConfigurations = %v, want the certificate %s", configs, certificateARN)
+					}
+				},
+			},
+			{
+				Name:    "already exists",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name: "security policy",
+				Change: func(r *resource) {
+					r.ko.Spec.DomainNameConfigurations[0].SecurityPolicy = aws.String("TLS_1_0")
+				},
+				WantCalls: []string{"UpdateDomainName"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.DomainNameConfigurations[0].SecurityPolicy); got != "TLS_1_0" {
+						t.Errorf("SecurityPolicy = %q, want TLS_1_0", got)
+					}
+				},
+			},
+			{
+				Name:      "tags only",
+				Change:    func(r *resource) { r.ko.Spec.Tags = map[string]*string{"team": aws.String("pets")} },
+				WantCalls: []string{"TagResource"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToStringMap(r.ko.Spec.Tags); !reflect.DeepEqual(got, map[string]string{"team": "pets"}) {
+						t.Errorf("Tags = %v, want map[team:pets]", got)
+					}
+				},
+			},
+			{
+				Name:      "domain name changed",
+				Change:    func(r *resource) { r.ko.Spec.DomainName = aws.String("www.example.com") },
+				WantErr:   ackerr.Terminal,
+				WantCalls: []string{},
+			},
+		},
+	}.Run(t)
+}
@@ -41,6 +41,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.APIID, b.ko.Spec.APIID) {
 		delta.Add("Spec.APIID", a.ko.Spec.APIID, b.ko.Spec.APIID)
@@ -90,6 +91,12 @@ func newResourceDelta(
 			delta.Add("Spec.Description", a.ko.Spec.Description, b.ko.Spec.Description)
 		}
 	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.FunctionAliasRef, b.ko.Spec.FunctionAliasRef) {
+		delta.Add("Spec.FunctionAliasRef", a.ko.Spec.FunctionAliasRef, b.ko.Spec.FunctionAliasRef)
+	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.FunctionRef, b.ko.Spec.FunctionRef) {
+		delta.Add("Spec.FunctionRef", a.ko.Spec.FunctionRef, b.ko.Spec.FunctionRef)
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.IntegrationMethod, b.ko.Spec.IntegrationMethod) {
 		delta.Add("Spec.IntegrationMethod", a.ko.Spec.IntegrationMethod, b.ko.Spec.IntegrationMethod)
 	} else if a.ko.Spec.IntegrationMethod != nil && b.ko.Spec.IntegrationMethod != nil {
@@ -118,6 +125,13 @@ func newResourceDelta(
 			delta.Add("Spec.IntegrationURI", a.ko.Spec.IntegrationURI, b.ko.Spec.IntegrationURI)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission) {
+		delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+	} else if a.ko.Spec.ManageInvokePermission != nil && b.ko.Spec.ManageInvokePermission != nil {
+		if *a.ko.Spec.ManageInvokePermission != *b.ko.Spec.ManageInvokePermission {
+			delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.PassthroughBehavior, b.ko.Spec.PassthroughBehavior) {
 		delta.Add("Spec.PassthroughBehavior", a.ko.Spec.PassthroughBehavior, b.ko.Spec.PassthroughBehavior)
 	} else if a.ko.Spec.PassthroughBehavior != nil && b.ko.Spec.PassthroughBehavior != nil {
@@ -0,0 +1,170 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// setResourceARN sets the ARN of the integration from its API and integration IDs.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.Integration) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/integrations/%s",
+		ko.Spec.APIID, ko.Status.IntegrationID,
+	)
+}
+
+// resolveReferenceForIntegrationURI reads the Lambda Function or Alias
+// referenced from FunctionRef or FunctionAliasRef and sets its ARN as the
+// IntegrationURI. Returns a boolean indicating whether the resource contains
+// references, or an error. At most one of IntegrationURI, FunctionRef and
+// FunctionAliasRef can be set.
+func (rm *resourceManager) resolveReferenceForIntegrationURI(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Integration,
+) (hasReferences bool, err error) {
+	switch {
+	case ko.Spec.FunctionRef != nil && ko.Spec.IntegrationURI != nil:
+		return true, ackerr.ResourceReferenceAndIDNotSupportedFor("IntegrationURI", "FunctionRef")
+	case ko.Spec.FunctionAliasRef != nil && ko.Spec.IntegrationURI != nil:
+		return true, ackerr.ResourceReferenceAndIDNotSupportedFor("IntegrationURI", "FunctionAliasRef")
+	case ko.Spec.FunctionRef != nil && ko.Spec.FunctionAliasRef != nil:
+		return true, fmt.Errorf("only one of FunctionRef and FunctionAliasRef can be set")
+	}
+	ref, gvk, field := ko.Spec.FunctionRef, lambda.FunctionGVK, "FunctionRef"
+	if ko.Spec.FunctionAliasRef != nil {
+		ref, gvk, field = ko.Spec.FunctionAliasRef, lambda.AliasGVK, "FunctionAliasRef"
+	}
+	if ref == nil || ref.From == nil {
+		return false, nil
+	}
+	hasReferences = true
+	arr := ref.From
+	if arr.Name == nil || *arr.Name == "" {
+		return hasReferences, fmt.Errorf("provided resource reference is nil or empty: %s", field)
+	}
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		ko.ObjectMeta.GetNamespace(),
+		arr.Namespace,
+		*arr.Name,
+	)
+	if err != nil {
+		return hasReferences, err
+	}
+	functionARN, err := lambda.ReferencedARN(ctx, apiReader, gvk, *arr.Name, namespace)
+	if err != nil {
+		return hasReferences, err
+	}
+	ko.Spec.IntegrationURI = &functionARN
+	return hasReferences, nil
+}
+
+// invokePermission returns the invoke permission managed for the integration.
+func invokePermission(ko *svcapitypes.Integration) lambda.InvokePermission {
+	return lambda.InvokePermission{
+		Kind:          "integration",
+		Manage:        ko.Spec.ManageInvokePermission,
+		Type:          ko.Spec.IntegrationType,
+		SupportedType: "AWS_PROXY",
+		URIField:      "IntegrationURI",
+		URI:           ko.Spec.IntegrationURI,
+		StatementID:   "apigatewayv2-integration-" + aws.ToString(ko.Status.IntegrationID),
+	}
+}
+
+// customPreCompare reports a difference at Spec.ManageInvokePermission when
+// the invoke permission recorded in the status does not match the desired
+// one, so that the permission is granted, moved or removed on update.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !invokePermission(a.ko).InSync(b.ko.Status.InvokePermissionFunctionARN) {
+		delta.Add("Spec.ManageInvokePermission", a.ko.Spec.ManageInvokePermission, b.ko.Spec.ManageInvokePermission)
+	}
+}
+
+// syncInvokePermission grants API Gateway the permission to invoke the
+// Lambda function of the integration and removes the permission previously
+// granted on another function. Any number of routes may target the
+// integration, and they are not known when the permission is granted, so the
+// permission is scoped to the API, any stage and route, rather than to a
+// single route.
+func (rm *resourceManager) syncInvokePermission(
+	ctx context.Context,
+	ko *svcapitypes.Integration,
+) (err error) {
+	p := invokePermission(ko)
+	p.SourceARN = lambda.ExecuteAPIARN(
+		string(rm.awsPartition), string(rm.awsRegion), string(rm.awsAccountID),
+		aws.ToString(ko.Spec.APIID), "*/*",
+	)
+	ko.Status.InvokePermissionFunctionARN, err = lambda.SyncInvokePermission(
+		ctx, rm.clientcfg, rm.metrics, p, ko.Status.InvokePermissionFunctionARN,
+	)
+	return err
+}
+
+// removeInvokePermission removes the invoke permission recorded in the
+// status of the integration, if any.
+func (rm *resourceManager) removeInvokePermission(
+	ctx context.Context,
+	ko *svcapitypes.Integration,
+) (err error) {
+	ko.Status.InvokePermissionFunctionARN, err = lambda.RemoveInvokePermission(
+		ctx, rm.clientcfg, rm.metrics, invokePermission(ko), ko.Status.InvokePermissionFunctionARN,
+	)
+	return err
+}
+
+// checkDependents blocks the deletion of the integration while Routes target
+// it or IntegrationResponses refer to it, which API Gateway would reject with
+// a ConflictException.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef, connectionRef, functionRef and functionAliasRef of the integration
+// in its ReferencesResolved condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Integration,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -62,6 +62,9 @@ func (rm *resourceManager) ResolveReferences(
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
 	ko := rm.concreteResource(res).ko
+	if err := rm.setReferencesResolved(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, true, err
+	}
 
 	resourceHasReferences := false
 	err := validateReferenceFields(ko)
@@ -77,6 +80,14 @@ func (rm *resourceManager) ResolveReferences(
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	// resolve the Lambda Function or Alias references into the
+	// IntegrationURI
+	if fieldHasReferences, err := rm.resolveReferenceForIntegrationURI(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	return &resource{ko}, resourceHasReferences, err
 }
 
@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/lambda"
+)
+
+func TestResolveReferenceForIntegrationURI(t *testing.T) {
+	const (
+		functionARN = "arn:aws:lambda:us-west-2:123456789012:function:pets"
+		aliasARN    = functionARN + ":live"
+	)
+	lambdaObject := func(gvk schema.GroupVersionKind, name, arn string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"ackResourceMetadata": map[string]interface{}{"arn": arn},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "ACK.ResourceSynced", "status": "True"},
+				},
+			},
+		}}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		return obj
+	}
+	apiReader := kubefake.NewClient(
+		lambdaObject(lambda.FunctionGVK, "pets", functionARN),
+		lambdaObject(lambda.AliasGVK, "pets-live", aliasARN),
+	)
+	ref := func(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+		return &ackv1alpha1.AWSResourceReferenceWrapper{From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)}}
+	}
+
+	tests := []struct {
+		name        string
+		spec        svcapitypes.IntegrationSpec
+		wantHasRefs bool
+		wantURI     string
+		wantErr     bool
+	}{
+		{
+			name:    "IntegrationURI",
+			spec:    svcapitypes.IntegrationSpec{IntegrationURI: aws.String("https://example.com")},
+			wantURI: "https://example.com",
+		},
+		{
+			name:        "FunctionRef",
+			spec:        svcapitypes.IntegrationSpec{FunctionRef: ref("pets")},
+			wantHasRefs: true,
+			wantURI:     functionARN,
+		},
+		{
+			name:        "FunctionAliasRef",
+			spec:        svcapitypes.IntegrationSpec{FunctionAliasRef: ref("pets-live")},
+			wantHasRefs: true,
+			wantURI:     aliasARN,
+		},
+		{
+			name:        "FunctionRef and IntegrationURI",
+			spec:        svcapitypes.IntegrationSpec{FunctionRef: ref("pets"), IntegrationURI: aws.String(functionARN)},
+			wantHasRefs: true,
+			wantURI:     functionARN,
+			wantErr:     true,
+		},
+		{
+			name:        "FunctionAliasRef and IntegrationURI",
+			spec:        svcapitypes.IntegrationSpec{FunctionAliasRef: ref("pets-live"), IntegrationURI: aws.String(aliasARN)},
+			wantHasRefs: true,
+			wantURI:     aliasARN,
+			wantErr:     true,
+		},
+		{
+			name:        "FunctionRef and FunctionAliasRef",
+			spec:        svcapitypes.IntegrationSpec{FunctionRef: ref("pets"), FunctionAliasRef: ref("pets-live")},
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+		{
+			name:        "Function missing",
+			spec:        svcapitypes.IntegrationSpec{FunctionRef: ref("orders")},
+			wantHasRefs: true,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.Integration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+				Spec:       tt.spec,
+			}
+			rm := &resourceManager{}
+			hasRefs, err := rm.resolveReferenceForIntegrationURI(context.Background(), apiReader, ko)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveReferenceForIntegrationURI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if hasRefs != tt.wantHasRefs {
+				t.Errorf("resolveReferenceForIntegrationURI() = %v, want %v", hasRefs, tt.wantHasRefs)
+			}
+			if got := aws.ToString(ko.Spec.IntegrationURI); got != tt.wantURI {
+				t.Errorf("IntegrationURI = %q, want %q", got, tt.wantURI)
+			}
+		})
+	}
+}
@@ -202,6 +202,7 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
 	return &resource{ko}, nil
 }
 
@@ -371,6 +372,10 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setResourceARN(ko)
+	if err := rm.syncInvokePermission(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
 	return &resource{ko}, nil
 }
 
@@ -466,6 +471,15 @@ func (rm *resourceManager) sdkUpdate(
 	defer func() {
 		exit(err)
 	}()
+	if immutableFieldChanges := rm.getImmutableFieldChanges(delta); len(immutableFieldChanges) > 0 {
+		msg := fmt.Sprintf("Immutable Spec fields have been modified: %s", strings.Join(immutableFieldChanges, ","))
+		return nil, ackerr.NewTerminalError(errors.New(msg))
+	}
+	if delta.DifferentAt("Spec.ManageInvokePermission") {
+		if err := rm.syncInvokePermission(ctx, desired.ko); err != nil {
+			return nil, err
+		}
+	}
 	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
 	if err != nil {
 		return nil, err
@@ -689,6 +703,12 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err := rm.checkDependents(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := rm.removeInvokePermission(ctx, r.ko); err != nil {
+		return nil, err
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -822,3 +842,16 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 	// No terminal_errors specified for this resource in generator config
 	return false
 }
+
+// getImmutableFieldChanges returns the immutable fields that differ between
+// the desired and latest state of the resource.
+func (rm *resourceManager) getImmutableFieldChanges(
+	delta *ackcompare.Delta,
+) []string {
+	var fields []string
+	if delta.DifferentAt("Spec.APIID") {
+		fields = append(fields, "APIID")
+	}
+
+	return fields
+}
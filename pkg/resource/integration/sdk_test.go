@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/smithy-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			return &resource{&svcapitypes.Integration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+				Spec: svcapitypes.IntegrationSpec{
+					APIID:                aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeHttp)),
+					IntegrationType:      aws.String("HTTP_PROXY"),
+					IntegrationMethod:    aws.String("ANY"),
+					IntegrationURI:       aws.String("https://example.com/{proxy}"),
+					PayloadFormatVersion: aws.String("1.0"),
+				},
+			}}
+		},
+		Missing: func(r *resource) { r.ko.Status.IntegrationID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToInt64(r.ko.Spec.TimeoutInMillis); got != 30000 {
+						t.Errorf("TimeoutInMillis = %d, want the HTTP API default", got)
+					}
+					if got := aws.ToString(r.ko.Spec.ConnectionType); got != "INTERNET" {
+						t.Errorf("ConnectionType = %q, want INTERNET", got)
+					}
+					if aws.ToBool(r.ko.Status.APIGatewayManaged) {
+						t.Errorf("APIGatewayManaged = true, want false")
+					}
+					if r.ko.Spec.TLSConfig != nil {
+						t.Errorf("TLSConfig = %+v, want unset", r.ko.Spec.TLSConfig)
+					}
+				},
+			},
+			{
+				Name: "with TLS configuration",
+				Change: func(r *resource) {
+					r.ko.Spec.TLSConfig = &svcapitypes.TLSConfigInput{ServerNameToVerify: aws.String("example.com")}
+				},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.TLSConfig == nil || aws.ToString(r.ko.Spec.TLSConfig.ServerNameToVerify) != "example.com" {
+						t.Errorf("TLSConfig = %+v, want the server name example.com", r.ko.Spec.TLSConfig)
+					}
+				},
+			},
+			{
+				Name:    "missing integration type",
+				Change:  func(r *resource) { r.ko.Spec.IntegrationType = nil },
+				WantErr: smithy.InvalidParamsError{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "timeout",
+				Change: func(r *resource) { r.ko.Spec.TimeoutInMillis = aws.Int64(5000) },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToInt64(r.ko.Spec.TimeoutInMillis); got != 5000 {
+						t.Errorf("TimeoutInMillis = %d, want 5000", got)
+					}
+				},
+			},
+			{
+				Name: "API changed",
+				Change: func(r *resource) {
+					r.ko.Spec.APIID = aws.String("other")
+					r.ko.Spec.TimeoutInMillis = aws.Int64(5000)
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToInt64(r.ko.Spec.TimeoutInMillis); got != 30000 {
+						t.Errorf("TimeoutInMillis = %d, want 30000", got)
+					}
+				},
+			},
+		},
+		Delete: []fake.Case[*resource]{
+			{
+				Name: "target of a route",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.CreateRoute(context.Background(), &svcsdk.CreateRouteInput{
+						ApiId:    r.ko.Spec.APIID,
+						RouteKey: aws.String("$default"),
+						Target:   aws.String("integrations/" + *r.ko.Status.IntegrationID),
+					}); err != nil {
+						f.T.Fatalf("CreateRoute() error = %v", err)
+					}
+				},
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name: "target of a Route resource",
+				Setup: func(f *fake.Fixture, _ *resource) {
+					f.Context = kubefake.NewContext(context.Background(), &svcapitypes.Route{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+						Spec: svcapitypes.RouteSpec{TargetRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+							From: &ackv1alpha1.AWSResourceReference{Name: aws.String("pets")},
+						}},
+					})
+				},
+				WantErr:   &ackrequeue.RequeueNeededAfter{},
+				WantCalls: []string{},
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration_response
+
+import (
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			apiID := f.CreateAPI(svcsdktypes.ProtocolTypeWebsocket)
+			return &resource{&svcapitypes.IntegrationResponse{Spec: svcapitypes.IntegrationResponseSpec{
+				APIID:                  aws.String(apiID),
+				IntegrationID:          aws.String(f.CreateIntegration(apiID)),
+				IntegrationResponseKey: aws.String("$default"),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.IntegrationResponseID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.IntegrationResponseKey); got != "$default" {
+						t.Errorf("IntegrationResponseKey = %q, want $default", got)
+					}
+				},
+			},
+			{
+				Name:    "key taken",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "missing integration",
+				Change:  func(r *resource) { r.ko.Spec.IntegrationID = aws.String("missing") },
+				WantErr: &svcsdktypes.NotFoundException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "content handling",
+				Change: func(r *resource) { r.ko.Spec.ContentHandlingStrategy = aws.String("CONVERT_TO_TEXT") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.ContentHandlingStrategy); got != "CONVERT_TO_TEXT" {
+						t.Errorf("ContentHandlingStrategy = %q, want CONVERT_TO_TEXT", got)
+					}
+				},
+			},
+			{
+				Name: "integration changed",
+				Change: func(r *resource) {
+					r.ko.Spec.IntegrationID = aws.String("other")
+					r.ko.Spec.ContentHandlingStrategy = aws.String("CONVERT_TO_TEXT")
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.ContentHandlingStrategy != nil {
+						t.Errorf("ContentHandlingStrategy = %q, want unset", *r.ko.Spec.ContentHandlingStrategy)
+					}
+				},
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/smithy-go"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+const petSchema = `{"$schema":"http://json-schema.org/draft-04/schema#","type":"object"}`
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			return &resource{&svcapitypes.Model{Spec: svcapitypes.ModelSpec{
+				APIID:       aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeWebsocket)),
+				Name:        aws.String("Pet"),
+				ContentType: aws.String("application/json"),
+				Schema:      aws.String(petSchema),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.ModelID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if aws.ToString(r.ko.Spec.Schema) != petSchema {
+						t.Errorf("Schema = %q, want %q", aws.ToString(r.ko.Spec.Schema), petSchema)
+					}
+				},
+			},
+			{
+				Name:    "name taken",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "missing schema",
+				Change:  func(r *resource) { r.ko.Spec.Schema = nil },
+				WantErr: smithy.InvalidParamsError{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "description",
+				Change: func(r *resource) { r.ko.Spec.Description = aws.String("a pet") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.Description); got != "a pet" {
+						t.Errorf("Description = %q, want %q", got, "a pet")
+					}
+				},
+			},
+			{
+				Name: "API changed",
+				Change: func(r *resource) {
+					r.ko.Spec.APIID = aws.String("other")
+					r.ko.Spec.Description = aws.String("a pet")
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.Description != nil {
+						t.Errorf("Description = %q, want unset", *r.ko.Spec.Description)
+					}
+				},
+			},
+		},
+	}.Run(t)
+}
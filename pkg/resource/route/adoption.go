@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"fmt"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/adoption"
+)
+
+// resolveNaturalKey sets the ID of a route being adopted by its route key
+// within the API. The route key is read from the adoption-fields annotation,
+// whose routeID is then left empty, or from the spec. The route is left
+// without an ID, and so reported as not found, if the API has no route with
+// the key.
+func (rm *resourceManager) resolveNaturalKey(
+	ctx context.Context,
+	r *resource,
+) (_ *resource, err error) {
+	if aws.ToString(r.ko.Status.RouteID) != "" || !adoption.Requested(r.ko) {
+		return r, nil
+	}
+	r = r.DeepCopy().(*resource)
+	r.ko.Status.RouteID = nil
+	r.ko.Spec.RouteKey = adoption.NaturalKey(r.ko, "routeKey", r.ko.Spec.RouteKey)
+	if r.ko.Spec.APIID == nil || r.ko.Spec.RouteKey == nil {
+		return r, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.resolveNaturalKey")
+	defer func() { exit(err) }()
+
+	var ids []string
+	input := &svcsdk.GetRoutesInput{ApiId: r.ko.Spec.APIID}
+	for {
+		resp, err := rm.sdkapi.GetRoutes(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetRoutes", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			if aws.ToString(item.RouteKey) == *r.ko.Spec.RouteKey {
+				ids = append(ids, aws.ToString(item.RouteId))
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	key := fmt.Sprintf("%q of API %s", *r.ko.Spec.RouteKey, *r.ko.Spec.APIID)
+	id, err := adoption.Unique("Route", key, ids)
+	if err != nil || id == "" {
+		return r, err
+	}
+	r.ko.Status.RouteID = aws.String(id)
+	return r, nil
+}
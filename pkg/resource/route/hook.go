@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	smithy "github.com/aws/smithy-go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// setResourceARN sets the ARN of the route from its API and route IDs.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.Route) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/routes/%s",
+		ko.Spec.APIID, ko.Status.RouteID,
+	)
+}
+
+// deleteRemovedRequestParameters calls DeleteRouteRequestParameter for every
+// key of Spec.RequestParameters that is set on the route in AWS but no longer
+// desired, since UpdateRoute can only add or change request parameters.
+// Every removed key is attempted; the keys that could not be deleted are
+// reported together in the returned error, which ends up in the
+// ACK.Recoverable condition of the route.
+func (rm *resourceManager) deleteRemovedRequestParameters(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	var removed []string
+	for key := range latest.ko.Spec.RequestParameters {
+		if _, ok := desired.ko.Spec.RequestParameters[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+
+	var errs []error
+	for _, key := range removed {
+		input := &svcsdk.DeleteRouteRequestParameterInput{
+			ApiId:               latest.ko.Spec.APIID,
+			RouteId:             latest.ko.Status.RouteID,
+			RequestParameterKey: &key,
+		}
+		_, err := rm.sdkapi.DeleteRouteRequestParameter(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "DeleteRouteRequestParameter", err)
+		if err != nil {
+			var awsErr smithy.APIError
+			if errors.As(err, &awsErr) && awsErr.ErrorCode() == "NotFoundException" {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf(
+			"unable to delete %d of %d removed request parameters: %w",
+			len(errs), len(removed), errors.Join(errs...),
+		)
+	}
+	return nil
+}
+
+// checkDependents blocks the deletion of the route while RouteResponses
+// refer to it with their routeRef.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef, authorizerRef and targetRef of the route in its ReferencesResolved
+// condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Route,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+func TestDeleteRemovedRequestParameters(t *testing.T) {
+	f := newFixture(t)
+	apiID := f.CreateAPI(svcsdktypes.ProtocolTypeHttp)
+	// route.request.header.b is already gone from the route in AWS.
+	out, err := f.Client.CreateRoute(context.Background(), &svcsdk.CreateRouteInput{
+		ApiId:    aws.String(apiID),
+		RouteKey: aws.String("GET /pets"),
+		RequestParameters: map[string]svcsdktypes.ParameterConstraints{
+			"route.request.querystring.q": {Required: aws.Bool(true)},
+			"route.request.header.a":      {Required: aws.Bool(true)},
+			"route.request.header.c":      {Required: aws.Bool(true)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoute() error = %v", err)
+	}
+	newRoute := func(keys ...string) *resource {
+		params := map[string]*svcapitypes.ParameterConstraints{}
+		for _, key := range keys {
+			params[key] = &svcapitypes.ParameterConstraints{Required: aws.Bool(true)}
+		}
+		return &resource{&svcapitypes.Route{
+			Spec:   svcapitypes.RouteSpec{APIID: aws.String(apiID), RequestParameters: params},
+			Status: svcapitypes.RouteStatus{RouteID: out.RouteId},
+		}}
+	}
+	desired := newRoute("route.request.querystring.q")
+	latest := newRoute(
+		"route.request.querystring.q",
+		"route.request.header.a",
+		"route.request.header.b",
+		"route.request.header.c",
+	)
+	// The removed keys are deleted in order, so the deletion of
+	// route.request.header.a fails.
+	f.Client.Fail("DeleteRouteRequestParameter", &svcsdktypes.ConflictException{Message: aws.String("conflict")})
+
+	err = f.Manager.(*resourceManager).deleteRemovedRequestParameters(f.Context, desired, latest)
+	if err == nil {
+		t.Fatal("deleteRemovedRequestParameters() error = nil, want partial failure")
+	}
+	var conflict *svcsdktypes.ConflictException
+	if !errors.As(err, &conflict) {
+		t.Errorf("deleteRemovedRequestParameters() error = %v, want the conflict wrapped", err)
+	}
+	if !strings.Contains(err.Error(), "1 of 3") || !strings.Contains(err.Error(), "route.request.header.a") {
+		t.Errorf("deleteRemovedRequestParameters() error = %q, want the failed key reported", err)
+	}
+	got, err := f.Client.GetRoute(context.Background(), &svcsdk.GetRouteInput{ApiId: aws.String(apiID), RouteId: out.RouteId})
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	var left []string
+	for key := range got.RequestParameters {
+		left = append(left, key)
+	}
+	sort.Strings(left)
+	if want := []string{"route.request.header.a", "route.request.querystring.q"}; !reflect.DeepEqual(left, want) {
+		t.Errorf("request parameters left = %v, want %v", left, want)
+	}
+}
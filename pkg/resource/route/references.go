@@ -66,6 +66,9 @@ func (rm *resourceManager) ResolveReferences(
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
 	ko := rm.concreteResource(res).ko
+	if err := rm.setReferencesResolved(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, true, err
+	}
 
 	resourceHasReferences := false
 	err := validateReferenceFields(ko)
@@ -0,0 +1,80 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+func TestResolveReferences_Condition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+			Status: svcapitypes.APIStatus{
+				APIID: aws.String("a1b2c3"),
+				Conditions: []*ackv1alpha1.Condition{{
+					Type:   ackv1alpha1.ConditionTypeResourceSynced,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		},
+		&svcapitypes.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+		},
+	).Build()
+	ref := func(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+		return &ackv1alpha1.AWSResourceReferenceWrapper{From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)}}
+	}
+	desired := &resource{&svcapitypes.Route{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pets"},
+		Spec: svcapitypes.RouteSpec{
+			APIRef:    ref("pets"),
+			TargetRef: ref("pets"),
+			RouteKey:  aws.String("GET /pets"),
+		},
+	}}
+
+	rm := &resourceManager{}
+	resolved, hasReferences, err := rm.ResolveReferences(context.Background(), apiReader, desired)
+	if !hasReferences || err == nil || err.Error() != ackerr.ResourceReferenceNotSyncedFor("Integration", "default", "pets").Error() {
+		t.Fatalf("ResolveReferences() = %v, %v, want the integration not synced", hasReferences, err)
+	}
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range resolved.(*resource).ko.Status.Conditions {
+		if c.Type == reference.ConditionTypeReferencesResolved {
+			cond = c
+		}
+	}
+	want := "spec.apiRef: API default/pets resolved; spec.targetRef: Integration default/pets not synced"
+	if cond == nil || cond.Status != corev1.ConditionFalse || *cond.Message != want {
+		t.Errorf("ReferencesResolved = %+v, want False %q", cond, want)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/routekey"
+)
+
+const (
+	// APIIDField is the name of the field index of the Routes on Spec.APIID.
+	APIIDField = "spec.apiID"
+	// APIRefField is the name of the field index of the Routes on the
+	// namespace and name of the API in Spec.APIRef.
+	APIRefField = "spec.apiRef.from"
+)
+
+// APIIDOf returns the values of the APIIDField index of a Route.
+func APIIDOf(obj client.Object) []string {
+	r, ok := obj.(*svcapitypes.Route)
+	if !ok || r.Spec.APIID == nil {
+		return nil
+	}
+	return []string{*r.Spec.APIID}
+}
+
+// APIRefOf returns the values of the APIRefField index of a Route.
+func APIRefOf(obj client.Object) []string {
+	r, ok := obj.(*svcapitypes.Route)
+	if !ok || r.Spec.APIRef == nil || r.Spec.APIRef.From == nil || r.Spec.APIRef.From.Name == nil {
+		return nil
+	}
+	return []string{apiRefKey(r.Namespace, r.Spec.APIRef.From)}
+}
+
+// apiRefKey returns the APIRefField value of a reference to an API made from
+// the namespace.
+func apiRefKey(namespace string, ref *ackv1alpha1.AWSResourceReference) string {
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		namespace = *ref.Namespace
+	}
+	return namespace + "/" + aws.ToString(ref.Name)
+}
+
+// validateRouteKey checks the syntax of Spec.RouteKey against the protocol of
+// the API and that no other Route of the same API claims the key, returning a
+// terminal error naming the conflicting Route otherwise.
+func (rm *resourceManager) validateRouteKey(
+	ctx context.Context,
+	ko *svcapitypes.Route,
+) (err error) {
+	if ko.Spec.APIID == nil || ko.Spec.RouteKey == nil {
+		return nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.validateRouteKey")
+	defer func() { exit(err) }()
+
+	resp, err := rm.sdkapi.GetApi(ctx, &svcsdk.GetApiInput{ApiId: ko.Spec.APIID})
+	rm.metrics.RecordAPICall("READ_ONE", "GetApi", err)
+	if err != nil {
+		return err
+	}
+	fldPath := field.NewPath("spec", "routeKey")
+	var errs field.ErrorList
+	if resp.ProtocolType == svcsdktypes.ProtocolTypeWebsocket {
+		errs = routekey.ValidateWebSocket(fldPath, *ko.Spec.RouteKey)
+	} else {
+		errs = routekey.ValidateHTTP(fldPath, *ko.Spec.RouteKey)
+	}
+	if len(errs) > 0 {
+		return ackerr.NewTerminalError(errs.ToAggregate())
+	}
+
+	kr, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	conflicting, err := conflictingRoute(ctx, kr, ko)
+	if err != nil {
+		return err
+	}
+	if conflicting != nil {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"route key %q of API %s is already used by Route %s/%s",
+			*ko.Spec.RouteKey, *ko.Spec.APIID, conflicting.Namespace, conflicting.Name,
+		))
+	}
+	return nil
+}
+
+// conflictingRoute returns the Route, in any namespace, that holds the route
+// key of ko for the same API, or nil. A Route that exists in AWS holds
+// its key; between Routes that are both pending creation, the oldest one
+// does. The Routes are looked up with the APIIDField and APIRefField indexes.
+func conflictingRoute(
+	ctx context.Context,
+	kr client.Reader,
+	ko *svcapitypes.Route,
+) (*svcapitypes.Route, error) {
+	routes, err := routesOfAPI(ctx, kr, ko)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range routes {
+		if other.UID == ko.UID ||
+			!other.DeletionTimestamp.IsZero() ||
+			aws.ToString(other.Spec.RouteKey) != *ko.Spec.RouteKey {
+			continue
+		}
+		if holdsRouteKey(other, ko) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// routesOfAPI returns the Routes of every namespace that belong to the API
+// of ko, whether they refer to it by ID or with Spec.APIRef. The values of
+// the APIRefField index carry the namespace of the API, so Routes referring
+// to it from other namespaces are found as well.
+func routesOfAPI(
+	ctx context.Context,
+	kr client.Reader,
+	ko *svcapitypes.Route,
+) ([]*svcapitypes.Route, error) {
+	apiID := *ko.Spec.APIID
+	selectors := []client.MatchingFields{{APIIDField: apiID}}
+	if ko.Spec.APIRef != nil && ko.Spec.APIRef.From != nil && ko.Spec.APIRef.From.Name != nil {
+		selectors = append(selectors, client.MatchingFields{APIRefField: apiRefKey(ko.Namespace, ko.Spec.APIRef.From)})
+	}
+	apis := &svcapitypes.APIList{}
+	if err := kr.List(ctx, apis); err != nil {
+		return nil, err
+	}
+	for _, api := range apis.Items {
+		if aws.ToString(api.Status.APIID) == apiID {
+			selectors = append(selectors, client.MatchingFields{APIRefField: api.Namespace + "/" + api.Name})
+		}
+	}
+
+	seen := map[types.UID]bool{}
+	var routes []*svcapitypes.Route
+	for _, sel := range selectors {
+		list := &svcapitypes.RouteList{}
+		if err := kr.List(ctx, list, sel); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			r := &list.Items[i]
+			if seen[r.UID] {
+				continue
+			}
+			seen[r.UID] = true
+			routes = append(routes, r)
+		}
+	}
+	return routes, nil
+}
+
+// holdsRouteKey returns true if other takes precedence over ko for a route
+// key they share.
+func holdsRouteKey(other, ko *svcapitypes.Route) bool {
+	switch {
+	case other.Status.RouteID != nil:
+		return true
+	case ko.Status.RouteID != nil:
+		return false
+	case !other.CreationTimestamp.Equal(&ko.CreationTimestamp):
+		return other.CreationTimestamp.Before(&ko.CreationTimestamp)
+	case other.Namespace != ko.Namespace:
+		return other.Namespace < ko.Namespace
+	}
+	return other.Name < ko.Name
+}
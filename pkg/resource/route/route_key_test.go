@@ -0,0 +1,122 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// newKubeClient returns a fake Kubernetes client holding the objects, with
+// the Route field indexes used to detect route key conflicts.
+func newKubeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		objs...,
+	).WithIndex(
+		&svcapitypes.Route{}, APIIDField, APIIDOf,
+	).WithIndex(
+		&svcapitypes.Route{}, APIRefField, APIRefOf,
+	).Build()
+}
+
+func TestConflictingRoute(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	newRoute := func(name, key string, age time.Duration) *svcapitypes.Route {
+		return &svcapitypes.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+			Spec: svcapitypes.RouteSpec{APIID: aws.String("api-1"), RouteKey: aws.String(key)},
+		}
+	}
+	byRef := newRoute("by-ref", "GET /refs", time.Hour)
+	byRef.Spec.APIID = nil
+	byRef.Spec.APIRef = &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String("api")},
+	}
+	otherAPI := newRoute("other-api", "GET /other", time.Hour)
+	otherAPI.Spec.APIID = aws.String("api-2")
+	otherNamespace := newRoute("other-namespace", "GET /namespaced", time.Hour)
+	otherNamespace.Namespace = "other"
+	inNamespace := func(r *svcapitypes.Route, namespace string) *svcapitypes.Route {
+		r.Namespace = namespace
+		return r
+	}
+	// crossRef refers to the API from another namespace.
+	crossRef := inNamespace(newRoute("cross-ref", "GET /cross", time.Hour), "team")
+	crossRef.Spec.APIID = nil
+	crossRef.Spec.APIRef = &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Namespace: aws.String("default"), Name: aws.String("api")},
+	}
+	tied := inNamespace(newRoute("tied", "GET /tied", 0), "b")
+	existing := newRoute("existing", "GET /existing", 0)
+	existing.Status.RouteID = aws.String("route-1")
+	api := &svcapitypes.API{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Status:     svcapitypes.APIStatus{APIID: aws.String("api-1")},
+	}
+
+	kc := newKubeClient(t, api, byRef, otherAPI, otherNamespace, crossRef, tied, existing, newRoute("older", "GET /pets", time.Hour))
+
+	tests := []struct {
+		name  string
+		route *svcapitypes.Route
+		want  string
+	}{
+		{"unique key", newRoute("unique", "GET /unique", 0), ""},
+		{"older pending route", newRoute("newer", "GET /pets", 0), "older"},
+		{"newer pending route", newRoute("oldest", "GET /pets", 2*time.Hour), ""},
+		{"route in AWS", newRoute("pending", "GET /existing", time.Hour), "existing"},
+		{"route referring to the API", newRoute("by-id", "GET /refs", 0), "by-ref"},
+		{"route of another API", newRoute("same-key", "GET /other", 2*time.Hour), ""},
+		{"route in another namespace", newRoute("namespaced", "GET /namespaced", 0), "other-namespace"},
+		{"route referring to the API from another namespace", newRoute("by-id", "GET /cross", 0), "cross-ref"},
+		{"route in a later namespace", inNamespace(newRoute("tie-a", "GET /tied", 0), "a"), ""},
+		{"route in an earlier namespace", inNamespace(newRoute("tie-c", "GET /tied", 0), "c"), "tied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conflictingRoute(context.Background(), kc, tt.route)
+			if err != nil {
+				t.Fatalf("conflictingRoute() error = %v", err)
+			}
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("conflictingRoute() = %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
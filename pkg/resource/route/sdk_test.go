@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+// newFixture returns a fixture whose Kubernetes client has the field
+// indexes of the routes.
+func newFixture(t *testing.T) *fake.Fixture {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	f.Context = kubeclient.IntoContext(context.Background(), newKubeClient(t))
+	return f
+}
+
+// checkRoute checks the route key and the request parameter keys of r.
+func checkRoute(t *testing.T, r *resource, wantKey string, wantParams ...string) {
+	t.Helper()
+	if got := aws.ToString(r.ko.Spec.RouteKey); got != wantKey {
+		t.Errorf("RouteKey = %q, want %q", got, wantKey)
+	}
+	var params []string
+	for k := range r.ko.Spec.RequestParameters {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	if !reflect.DeepEqual(params, wantParams) {
+		t.Errorf("request parameters = %v, want %v", params, wantParams)
+	}
+}
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			f.Context = kubeclient.IntoContext(context.Background(), newKubeClient(f.T))
+			return &resource{&svcapitypes.Route{Spec: svcapitypes.RouteSpec{
+				APIID:    aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeHttp)),
+				RouteKey: aws.String("GET /pets"),
+				RequestParameters: map[string]*svcapitypes.ParameterConstraints{
+					"route.request.header.a": {Required: aws.Bool(true)},
+				},
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.RouteID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					checkRoute(t, r, "GET /pets", "route.request.header.a")
+				},
+			},
+			{
+				Name:    "key taken in AWS",
+				Setup:   func(f *fake.Fixture, r *resource) { f.CreateRoute(*r.ko.Spec.APIID, "GET /pets") },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "invalid key",
+				Change:  func(r *resource) { r.ko.Spec.RouteKey = aws.String("GET pets") },
+				WantErr: ackerr.Terminal,
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:   "operation name",
+				Change: func(r *resource) { r.ko.Spec.OperationName = aws.String("listPets") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.OperationName); got != "listPets" {
+						t.Errorf("OperationName = %q, want listPets", got)
+					}
+					checkRoute(t, r, "GET /pets", "route.request.header.a")
+				},
+			},
+			{
+				Name:   "route key",
+				Change: func(r *resource) { r.ko.Spec.RouteKey = aws.String("GET /cats") },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					checkRoute(t, r, "GET /cats", "route.request.header.a")
+				},
+			},
+			{
+				Name: "request parameter replaced",
+				Change: func(r *resource) {
+					r.ko.Spec.RequestParameters = map[string]*svcapitypes.ParameterConstraints{
+						"route.request.querystring.q": {Required: aws.Bool(false)},
+					}
+				},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					checkRoute(t, r, "GET /pets", "route.request.querystring.q")
+				},
+			},
+			{
+				Name:    "API changed",
+				Change:  func(r *resource) { r.ko.Spec.APIID = aws.String("other") },
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					checkRoute(t, r, "GET /pets", "route.request.header.a")
+				},
+			},
+		},
+	}.Run(t)
+}
+
+func TestSdkFind_AdoptedByRouteKey(t *testing.T) {
+	f := newFixture(t)
+	apiID := f.CreateAPI(svcsdktypes.ProtocolTypeHttp)
+	routeID := f.CreateRoute(apiID, "GET /pets")
+	byFields := func(routeKey string) *resource {
+		return f.Adopt(&resource{&svcapitypes.Route{}}, "adopt", map[string]string{
+			"apiID": apiID, "routeID": "", "routeKey": routeKey,
+		}).(*resource)
+	}
+	bySpec := &resource{&svcapitypes.Route{
+		Spec: svcapitypes.RouteSpec{APIID: aws.String(apiID), RouteKey: aws.String("GET /pets")},
+	}}
+
+	tests := []struct {
+		name        string
+		r           *resource
+		wantRouteID string
+		wantErr     error
+	}{
+		{name: "route key in the adoption fields", r: byFields("GET /pets"), wantRouteID: routeID},
+		{name: "route key in the spec", r: f.Adopt(bySpec, "adopt-or-create", nil).(*resource), wantRouteID: routeID},
+		{name: "missing route key", r: byFields("PUT /pets"), wantErr: ackerr.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, err := f.Manager.ReadOne(f.Context, tt.r)
+			if err != tt.wantErr {
+				t.Fatalf("ReadOne() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := aws.ToString(latest.(*resource).ko.Status.RouteID); got != tt.wantRouteID {
+				t.Errorf("RouteID = %q, want %q", got, tt.wantRouteID)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route_response
+
+import (
+	"context"
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// resolveReferenceForResponseModels reads the Model resources referenced from
+// the ResponseModelRefs field and returns a copy of ko with the name of each
+// referenced Model set as the ResponseModels entry, and the
+// Status.ResolvedResponseModels entry, for the same selection key. Returns
+// also a boolean indicating whether the resource contains references, or an
+// error
+func (rm *resourceManager) resolveReferenceForResponseModels(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.RouteResponse,
+) (resolved *svcapitypes.RouteResponse, hasReferences bool, err error) {
+	if len(ko.Spec.ResponseModelRefs) == 0 {
+		return ko, false, nil
+	}
+	hasReferences = true
+	for key := range ko.Spec.ResponseModelRefs {
+		if _, ok := ko.Spec.ResponseModels[key]; ok {
+			return ko, hasReferences, ackerr.NewTerminalError(fmt.Errorf(
+				"response model %q cannot be set in both ResponseModels and ResponseModelRefs", key,
+			))
+		}
+	}
+	resolved = ko.DeepCopy()
+	if resolved.Spec.ResponseModels == nil {
+		resolved.Spec.ResponseModels = make(map[string]*string, len(ko.Spec.ResponseModelRefs))
+	}
+	resolved.Status.ResolvedResponseModels = make(map[string]*string, len(ko.Spec.ResponseModelRefs))
+	for key, ref := range ko.Spec.ResponseModelRefs {
+		if ref == nil || ref.From == nil {
+			return ko, hasReferences, fmt.Errorf("provided resource reference is nil or empty: ResponseModelRefs[%s]", key)
+		}
+		arr := ref.From
+		if arr.Name == nil || *arr.Name == "" {
+			return ko, hasReferences, fmt.Errorf("provided resource reference is nil or empty: ResponseModelRefs[%s]", key)
+		}
+		namespace, err := ackrt.ResolveCrossNamespaceReference(
+			ctx,
+			rm.cfg.EnableCrossNamespace,
+			&ko.Status.Conditions,
+			ackrt.CrossNamespaceRefKindResource,
+			ko.ObjectMeta.GetNamespace(),
+			arr.Namespace,
+			*arr.Name,
+		)
+		if err != nil {
+			return ko, hasReferences, err
+		}
+		obj := &svcapitypes.Model{}
+		if err := getReferencedResourceState_Model(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+			return ko, hasReferences, err
+		}
+		resolved.Spec.ResponseModels[key] = obj.Spec.Name
+		resolved.Status.ResolvedResponseModels[key] = obj.Spec.Name
+	}
+	return resolved, hasReferences, nil
+}
+
+// clearResolvedResponseModels moves the ResponseModels entries of the
+// selection keys in ResponseModelRefs to Status.ResolvedResponseModels, so
+// that the model names resolved from the references are not written to the
+// spec of the resource.
+func clearResolvedResponseModels(ko *svcapitypes.RouteResponse) {
+	if len(ko.Spec.ResponseModelRefs) == 0 {
+		ko.Status.ResolvedResponseModels = nil
+		return
+	}
+	resolved := map[string]*string{}
+	for key := range ko.Spec.ResponseModelRefs {
+		if model, ok := ko.Spec.ResponseModels[key]; ok {
+			resolved[key] = model
+			delete(ko.Spec.ResponseModels, key)
+		}
+	}
+	if len(ko.Spec.ResponseModels) == 0 {
+		ko.Spec.ResponseModels = nil
+	}
+	ko.Status.ResolvedResponseModels = resolved
+}
+
+// customPreCompare compares the response models of the desired and latest
+// route responses, including the ones resolved from ResponseModelRefs that
+// are kept in Status.ResolvedResponseModels.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !ackcompare.MapStringStringPEqual(appliedResponseModels(a.ko), appliedResponseModels(b.ko)) {
+		delta.Add("Spec.ResponseModels", a.ko.Spec.ResponseModels, b.ko.Spec.ResponseModels)
+	}
+}
+
+// appliedResponseModels returns the response models of the route response,
+// taking the models of the selection keys in ResponseModelRefs from
+// Status.ResolvedResponseModels.
+func appliedResponseModels(ko *svcapitypes.RouteResponse) map[string]*string {
+	models := make(map[string]*string, len(ko.Spec.ResponseModels)+len(ko.Status.ResolvedResponseModels))
+	for key, model := range ko.Spec.ResponseModels {
+		if _, ok := ko.Spec.ResponseModelRefs[key]; !ok {
+			models[key] = model
+		}
+	}
+	for key, model := range ko.Status.ResolvedResponseModels {
+		if _, ok := ko.Spec.ResponseModelRefs[key]; ok {
+			models[key] = model
+		}
+	}
+	return models
+}
+
+// setResourceARN sets the ARN of the route response from its API, route
+// and response IDs.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.RouteResponse) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/routes/%s/routeresponses/%s",
+		ko.Spec.APIID, ko.Spec.RouteID, ko.Status.RouteResponseID,
+	)
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef and routeRef of the route response in its ReferencesResolved condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.RouteResponse,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
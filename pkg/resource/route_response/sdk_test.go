@@ -0,0 +1,172 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package route_response
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			apiID := f.CreateAPI(svcsdktypes.ProtocolTypeWebsocket)
+			return &resource{&svcapitypes.RouteResponse{Spec: svcapitypes.RouteResponseSpec{
+				APIID:            aws.String(apiID),
+				RouteID:          aws.String(f.CreateRoute(apiID, "$default")),
+				RouteResponseKey: aws.String("$default"),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Status.RouteResponseID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.RouteResponseKey); got != "$default" {
+						t.Errorf("RouteResponseKey = %q, want $default", got)
+					}
+				},
+			},
+			{
+				Name:    "key taken",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "missing route",
+				Change:  func(r *resource) { r.ko.Spec.RouteID = aws.String("missing") },
+				WantErr: &svcsdktypes.NotFoundException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name: "response models",
+				Change: func(r *resource) {
+					r.ko.Spec.ResponseModels = map[string]*string{"application/json": aws.String("Pet")}
+				},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					want := map[string]string{"application/json": "Pet"}
+					if got := aws.ToStringMap(r.ko.Spec.ResponseModels); !reflect.DeepEqual(got, want) {
+						t.Errorf("ResponseModels = %v, want %v", got, want)
+					}
+				},
+			},
+			{
+				Name: "route changed",
+				Change: func(r *resource) {
+					r.ko.Spec.RouteID = aws.String("other")
+					r.ko.Spec.ResponseModels = map[string]*string{"application/json": aws.String("Pet")}
+				},
+				WantErr: ackerr.Terminal,
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.ResponseModels != nil {
+						t.Errorf("ResponseModels = %v, want unset", aws.ToStringMap(r.ko.Spec.ResponseModels))
+					}
+				},
+			},
+		},
+	}.Run(t)
+}
+
+func TestSdk_ResponseModelRefs(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	kc := kubefake.NewClient(&svcapitypes.Model{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pet"},
+		Spec:       svcapitypes.ModelSpec{Name: aws.String("Pet")},
+		Status: svcapitypes.ModelStatus{Conditions: []*ackv1alpha1.Condition{{
+			Type:   ackv1alpha1.ConditionTypeResourceSynced,
+			Status: corev1.ConditionTrue,
+		}}},
+	})
+	apiID := f.CreateAPI(svcsdktypes.ProtocolTypeWebsocket)
+	desired := &resource{&svcapitypes.RouteResponse{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "default"},
+		Spec: svcapitypes.RouteResponseSpec{
+			APIID:            aws.String(apiID),
+			RouteID:          aws.String(f.CreateRoute(apiID, "$default")),
+			RouteResponseKey: aws.String("$default"),
+			ResponseModels:   map[string]*string{"text/plain": aws.String("Empty")},
+			ResponseModelRefs: map[string]*ackv1alpha1.AWSResourceReferenceWrapper{
+				"application/json": {From: &ackv1alpha1.AWSResourceReference{Name: aws.String("pet")}},
+			},
+		},
+	}}
+	resolved, _, err := f.Manager.ResolveReferences(f.Context, kc, desired)
+	if err != nil {
+		t.Fatalf("ResolveReferences() error = %v", err)
+	}
+	if got := aws.ToStringMap(desired.ko.Spec.ResponseModels); !reflect.DeepEqual(got, map[string]string{"text/plain": "Empty"}) {
+		t.Fatalf("ResolveReferences() changed the ResponseModels of the desired resource to %v", got)
+	}
+	// checkCleared checks that the model resolved from ResponseModelRefs is
+	// kept out of the spec, in Status.ResolvedResponseModels.
+	checkCleared := func(op string, r acktypes.AWSResource, wantResolved string) {
+		t.Helper()
+		ko := r.(*resource).ko
+		if got, want := aws.ToStringMap(ko.Spec.ResponseModels), map[string]string{"text/plain": "Empty"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ResponseModels = %v, want %v", op, got, want)
+		}
+		if got, want := aws.ToStringMap(ko.Status.ResolvedResponseModels), map[string]string{"application/json": wantResolved}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ResolvedResponseModels = %v, want %v", op, got, want)
+		}
+	}
+
+	created, err := f.Manager.Create(f.Context, resolved)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	checkCleared("Create", created, "Pet")
+	latest := f.ReadOne(created)
+	checkCleared("ReadOne", latest, "Pet")
+	if delta := f.Descriptor.Delta(resolved, latest); delta.DifferentAt("Spec.ResponseModels") {
+		t.Errorf("delta differs at Spec.ResponseModels for applied models: %v", delta.Differences)
+	}
+
+	// A model changed out of band is set back to the referenced one.
+	ko := latest.(*resource).ko
+	if _, err := f.Client.UpdateRouteResponse(context.Background(), &svcsdk.UpdateRouteResponseInput{
+		ApiId:           ko.Spec.APIID,
+		RouteId:         ko.Spec.RouteID,
+		RouteResponseId: ko.Status.RouteResponseID,
+		ResponseModels:  map[string]string{"application/json": "Other", "text/plain": "Empty"},
+	}); err != nil {
+		t.Fatalf("UpdateRouteResponse() error = %v", err)
+	}
+	latest = f.ReadOne(created)
+	checkCleared("ReadOne", latest, "Other")
+	if delta := f.Descriptor.Delta(resolved, latest); !delta.DifferentAt("Spec.ResponseModels") {
+		t.Fatalf("delta does not differ at Spec.ResponseModels for a changed model")
+	}
+	resolved.(*resource).ko.Status.RouteResponseID = ko.Status.RouteResponseID
+	updated, err := f.Update(resolved, latest)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	checkCleared("Update", updated, "Pet")
+}
@@ -41,6 +41,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.AccessLogSettings, b.ko.Spec.AccessLogSettings) {
 		delta.Add("Spec.AccessLogSettings", a.ko.Spec.AccessLogSettings, b.ko.Spec.AccessLogSettings)
@@ -140,13 +141,6 @@ func newResourceDelta(
 			delta.Add("Spec.Description", a.ko.Spec.Description, b.ko.Spec.Description)
 		}
 	}
-	if len(a.ko.Spec.RouteSettings) != len(b.ko.Spec.RouteSettings) {
-		delta.Add("Spec.RouteSettings", a.ko.Spec.RouteSettings, b.ko.Spec.RouteSettings)
-	} else if len(a.ko.Spec.RouteSettings) > 0 {
-		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.RouteSettings, b.ko.Spec.RouteSettings) {
-			delta.Add("Spec.RouteSettings", a.ko.Spec.RouteSettings, b.ko.Spec.RouteSettings)
-		}
-	}
 	if ackcompare.HasNilDifference(a.ko.Spec.StageName, b.ko.Spec.StageName) {
 		delta.Add("Spec.StageName", a.ko.Spec.StageName, b.ko.Spec.StageName)
 	} else if a.ko.Spec.StageName != nil && b.ko.Spec.StageName != nil {
@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stage
+
+import (
+	"context"
+	"sort"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/tags"
+)
+
+// syncTags syncs the tags of the stage in AWS with its desired tags.
+func (rm *resourceManager) syncTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	desiredTags, _ := convertToOrderedACKTags(desired.ko.Spec.Tags)
+	latestTags, _ := convertToOrderedACKTags(latest.ko.Spec.Tags)
+	return tags.SyncTags(
+		ctx, rm.sdkapi, rm.metrics, rm.cfg.ResourceTagKeys,
+		latest.ko.Status.ACKResourceMetadata.ARN, desiredTags, latestTags,
+	)
+}
+
+// setResourceARN sets the ARN of the stage from its API ID and name.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.Stage) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/apis/%s/stages/%s",
+		ko.Spec.APIID, ko.Spec.StageName,
+	)
+}
+
+// customPreCompare compares the route settings of the desired and latest
+// stages. Only the route keys and fields set in the desired spec are
+// compared, so the entries and defaults API Gateway adds on its own do not
+// show up as a permanent difference. Route keys that still carry settings in
+// AWS but are no longer desired are reported as a difference so that they
+// can be deleted.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !routeSettingsInSync(a.ko.Spec.RouteSettings, b.ko.Spec.RouteSettings) {
+		delta.Add("Spec.RouteSettings", a.ko.Spec.RouteSettings, b.ko.Spec.RouteSettings)
+	}
+}
+
+// routeSettingsInSync returns true if every desired route setting is applied
+// in latest and latest has no settings for route keys that are not desired.
+func routeSettingsInSync(
+	desired map[string]*svcapitypes.RouteSettings,
+	latest map[string]*svcapitypes.RouteSettings,
+) bool {
+	for routeKey, d := range desired {
+		l, ok := latest[routeKey]
+		if !ok {
+			if isEmptyRouteSettings(d) {
+				continue
+			}
+			return false
+		}
+		if d == nil {
+			continue
+		}
+		if l == nil {
+			l = &svcapitypes.RouteSettings{}
+		}
+		if d.DataTraceEnabled != nil && !equalPtr(d.DataTraceEnabled, l.DataTraceEnabled) ||
+			d.DetailedMetricsEnabled != nil && !equalPtr(d.DetailedMetricsEnabled, l.DetailedMetricsEnabled) ||
+			d.LoggingLevel != nil && !equalPtr(d.LoggingLevel, l.LoggingLevel) ||
+			d.ThrottlingBurstLimit != nil && !equalPtr(d.ThrottlingBurstLimit, l.ThrottlingBurstLimit) ||
+			d.ThrottlingRateLimit != nil && !equalPtr(d.ThrottlingRateLimit, l.ThrottlingRateLimit) {
+			return false
+		}
+	}
+	return len(removedRouteSettings(desired, latest)) == 0
+}
+
+// removedRouteSettings returns, in order, the route keys that have settings
+// in latest but are missing from desired.
+func removedRouteSettings(
+	desired map[string]*svcapitypes.RouteSettings,
+	latest map[string]*svcapitypes.RouteSettings,
+) []string {
+	var removed []string
+	for routeKey, l := range latest {
+		if _, ok := desired[routeKey]; ok || isEmptyRouteSettings(l) {
+			continue
+		}
+		removed = append(removed, routeKey)
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// isEmptyRouteSettings returns true if none of the route settings is set.
+// Settings explicitly set to false, OFF or 0 are not empty: they override the
+// stage defaults.
+func isEmptyRouteSettings(rs *svcapitypes.RouteSettings) bool {
+	return rs == nil ||
+		rs.DataTraceEnabled == nil &&
+			rs.DetailedMetricsEnabled == nil &&
+			rs.LoggingLevel == nil &&
+			rs.ThrottlingBurstLimit == nil &&
+			rs.ThrottlingRateLimit == nil
+}
+
+// deleteRemovedRouteSettings calls DeleteRouteSettings for every route key
+// that was removed from Spec.RouteSettings, since UpdateStage merges the
+// route settings and never removes them.
+func (rm *resourceManager) deleteRemovedRouteSettings(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	for _, routeKey := range removedRouteSettings(desired.ko.Spec.RouteSettings, latest.ko.Spec.RouteSettings) {
+		input := &svcsdk.DeleteRouteSettingsInput{
+			ApiId:     latest.ko.Spec.APIID,
+			StageName: latest.ko.Spec.StageName,
+			RouteKey:  &routeKey,
+		}
+		_, err := rm.sdkapi.DeleteRouteSettings(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "DeleteRouteSettings", err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// setReferencesResolved records the state of the resources referred to by the
+// apiRef and deploymentRef of the stage in its ReferencesResolved condition.
+func (rm *resourceManager) setReferencesResolved(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Stage,
+) error {
+	return reference.SetResolvedCondition(ctx, apiReader, GroupKind.Kind, ko, &ko.Status.Conditions)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+func TestRouteSettingsDelta(t *testing.T) {
+	throttled := func(burst int64) *svcapitypes.RouteSettings {
+		return &svcapitypes.RouteSettings{ThrottlingBurstLimit: aws.Int64(burst)}
+	}
+	disabled := func() *svcapitypes.RouteSettings {
+		return &svcapitypes.RouteSettings{
+			DataTraceEnabled:       aws.Bool(false),
+			DetailedMetricsEnabled: aws.Bool(false),
+			LoggingLevel:           aws.String("OFF"),
+			ThrottlingBurstLimit:   aws.Int64(0),
+			ThrottlingRateLimit:    aws.Float64(0),
+		}
+	}
+	withDefaults := func(rs *svcapitypes.RouteSettings) *svcapitypes.RouteSettings {
+		rs.DetailedMetricsEnabled = aws.Bool(false)
+		rs.LoggingLevel = aws.String("OFF")
+		return rs
+	}
+
+	tests := []struct {
+		name        string
+		desired     map[string]*svcapitypes.RouteSettings
+		latest      map[string]*svcapitypes.RouteSettings
+		wantDelta   bool
+		wantRemoved []string
+	}{
+		{
+			name:    "in sync with AWS defaults",
+			desired: map[string]*svcapitypes.RouteSettings{"GET /pets": throttled(10)},
+			latest: map[string]*svcapitypes.RouteSettings{
+				"GET /pets":  withDefaults(throttled(10)),
+				"POST /pets": {},
+			},
+		},
+		{
+			name:      "changed",
+			desired:   map[string]*svcapitypes.RouteSettings{"GET /pets": throttled(20)},
+			latest:    map[string]*svcapitypes.RouteSettings{"GET /pets": throttled(10)},
+			wantDelta: true,
+		},
+		{
+			name:      "added",
+			desired:   map[string]*svcapitypes.RouteSettings{"GET /pets": throttled(10)},
+			latest:    nil,
+			wantDelta: true,
+		},
+		{
+			name:      "explicitly disabled",
+			desired:   map[string]*svcapitypes.RouteSettings{"GET /pets": disabled()},
+			latest:    nil,
+			wantDelta: true,
+		},
+		{
+			name:    "explicitly disabled in sync",
+			desired: map[string]*svcapitypes.RouteSettings{"GET /pets": disabled()},
+			latest:  map[string]*svcapitypes.RouteSettings{"GET /pets": disabled()},
+		},
+		{
+			name:        "explicitly disabled removed",
+			desired:     nil,
+			latest:      map[string]*svcapitypes.RouteSettings{"GET /pets": disabled()},
+			wantDelta:   true,
+			wantRemoved: []string{"GET /pets"},
+		},
+		{
+			name:    "removed",
+			desired: map[string]*svcapitypes.RouteSettings{"GET /pets": throttled(10)},
+			latest: map[string]*svcapitypes.RouteSettings{
+				"GET /pets":    throttled(10),
+				"DELETE /pets": throttled(1),
+				"POST /pets":   throttled(5),
+			},
+			wantDelta:   true,
+			wantRemoved: []string{"DELETE /pets", "POST /pets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{&svcapitypes.Stage{Spec: svcapitypes.StageSpec{RouteSettings: tt.desired}}}
+			latest := &resource{&svcapitypes.Stage{Spec: svcapitypes.StageSpec{RouteSettings: tt.latest}}}
+
+			delta := newResourceDelta(desired, latest)
+			if got := delta.DifferentAt("Spec.RouteSettings"); got != tt.wantDelta {
+				t.Errorf("DifferentAt(Spec.RouteSettings) = %v, want %v", got, tt.wantDelta)
+			}
+			if got := removedRouteSettings(tt.desired, tt.latest); !reflect.DeepEqual(got, tt.wantRemoved) {
+				t.Errorf("removedRouteSettings() = %v, want %v", got, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+// createDeployment creates a deployment of the API of r in the fake and
+// returns its ID.
+func createDeployment(f *fake.Fixture, r *resource) string {
+	f.T.Helper()
+	out, err := f.Client.CreateDeployment(context.Background(), &svcsdk.CreateDeploymentInput{ApiId: r.ko.Spec.APIID})
+	if err != nil {
+		f.T.Fatalf("CreateDeployment() error = %v", err)
+	}
+	return *out.DeploymentId
+}
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New: func(f *fake.Fixture) *resource {
+			return &resource{&svcapitypes.Stage{Spec: svcapitypes.StageSpec{
+				APIID:     aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeHttp)),
+				StageName: aws.String("prod"),
+			}}}
+		},
+		Missing: func(r *resource) { r.ko.Spec.StageName = aws.String("dev") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					wantARN := "arn:aws:apigateway:us-west-2::/apis/" + *r.ko.Spec.APIID + "/stages/prod"
+					if got := string(*r.ko.Status.ACKResourceMetadata.ARN); got != wantARN {
+						t.Errorf("ARN = %q, want %q", got, wantARN)
+					}
+					if r.ko.Status.CreatedDate == nil {
+						t.Errorf("CreatedDate not set")
+					}
+				},
+			},
+			{
+				Name:  "with deployment",
+				Setup: func(f *fake.Fixture, r *resource) { r.ko.Spec.DeploymentID = aws.String(createDeployment(f, r)) },
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if r.ko.Spec.DeploymentID == nil {
+						t.Errorf("DeploymentID not set")
+					}
+				},
+			},
+			{
+				Name:    "name taken",
+				Setup:   func(f *fake.Fixture, r *resource) { f.Create(r.DeepCopy()) },
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+			{
+				Name:    "missing deployment",
+				Change:  func(r *resource) { r.ko.Spec.DeploymentID = aws.String("missing") },
+				WantErr: &svcsdktypes.BadRequestException{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:      "description",
+				Change:    func(r *resource) { r.ko.Spec.Description = aws.String("production") },
+				WantCalls: []string{"UpdateStage"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Spec.Description); got != "production" {
+						t.Errorf("Description = %q, want production", got)
+					}
+				},
+			},
+			{
+				Name: "route settings removed",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.UpdateStage(context.Background(), &svcsdk.UpdateStageInput{
+						ApiId:     r.ko.Spec.APIID,
+						StageName: r.ko.Spec.StageName,
+						RouteSettings: map[string]svcsdktypes.RouteSettings{
+							"GET /pets":  {ThrottlingBurstLimit: aws.Int32(10)},
+							"POST /pets": {ThrottlingBurstLimit: aws.Int32(5)},
+						},
+					}); err != nil {
+						f.T.Fatalf("UpdateStage() error = %v", err)
+					}
+				},
+				Change: func(r *resource) {
+					r.ko.Spec.RouteSettings = map[string]*svcapitypes.RouteSettings{
+						"GET /pets": {ThrottlingBurstLimit: aws.Int64(20)},
+					}
+				},
+				WantCalls: []string{"DeleteRouteSettings", "UpdateStage"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					var keys []string
+					for k := range r.ko.Spec.RouteSettings {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					if !reflect.DeepEqual(keys, []string{"GET /pets"}) {
+						t.Fatalf("route settings = %v, want [GET /pets]", keys)
+					}
+					if got := aws.ToInt64(r.ko.Spec.RouteSettings["GET /pets"].ThrottlingBurstLimit); got != 20 {
+						t.Errorf("ThrottlingBurstLimit = %d, want 20", got)
+					}
+				},
+			},
+			{
+				Name:      "tags only",
+				Change:    func(r *resource) { r.ko.Spec.Tags = map[string]*string{"team": aws.String("pets")} },
+				WantCalls: []string{"TagResource"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToStringMap(r.ko.Spec.Tags); !reflect.DeepEqual(got, map[string]string{"team": "pets"}) {
+						t.Errorf("Tags = %v, want map[team:pets]", got)
+					}
+				},
+			},
+			{
+				Name: "auto deploy ignores the deployment",
+				Change: func(r *resource) {
+					r.ko.Spec.AutoDeploy = aws.Bool(true)
+					r.ko.Spec.DeploymentID = aws.String("ignored")
+				},
+				WantCalls: []string{"UpdateStage"},
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if !aws.ToBool(r.ko.Spec.AutoDeploy) || r.ko.Spec.DeploymentID != nil {
+						t.Errorf("AutoDeploy = %v, DeploymentID = %v, want auto deploy without deployment",
+							aws.ToBool(r.ko.Spec.AutoDeploy), aws.ToString(r.ko.Spec.DeploymentID))
+					}
+				},
+			},
+		},
+	}.Run(t)
+}
+
+func TestSdkUpdate_ImmutableFields(t *testing.T) {
+	newStage := func(apiID, name string) *resource {
+		return &resource{&svcapitypes.Stage{Spec: svcapitypes.StageSpec{
+			APIID:     aws.String(apiID),
+			StageName: aws.String(name),
+		}}}
+	}
+	desired := newStage("api-2", "prod")
+	latest := newStage("api-1", "dev")
+
+	// No API call is made, so the resource manager needs no client.
+	rm := &resourceManager{}
+	_, err := rm.sdkUpdate(context.Background(), desired, latest, newResourceDelta(desired, latest))
+	var terminal *ackerr.TerminalError
+	if !errors.As(err, &terminal) {
+		t.Fatalf("sdkUpdate() error = %v, want a terminal error", err)
+	}
+	if !strings.Contains(err.Error(), "APIID,StageName") {
+		t.Errorf("sdkUpdate() error = %q, want the modified fields listed", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package vpc_link
+
+import (
+	"context"
+	"fmt"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/adoption"
+)
+
+// resolveNaturalKey sets the ID of a VPC link being adopted by name. The
+// name is read from the adoption-fields annotation, whose vpcLinkID is then
+// left empty, or from the spec. The VPC link is left without an ID, and so
+// reported as not found, if no VPC link matches, while several matching VPC
+// links make the adoption fail.
+func (rm *resourceManager) resolveNaturalKey(
+	ctx context.Context,
+	r *resource,
+) (_ *resource, err error) {
+	if aws.ToString(r.ko.Status.VPCLinkID) != "" || !adoption.Requested(r.ko) {
+		return r, nil
+	}
+	r = r.DeepCopy().(*resource)
+	r.ko.Status.VPCLinkID = nil
+	r.ko.Spec.Name = adoption.NaturalKey(r.ko, "name", r.ko.Spec.Name)
+	if r.ko.Spec.Name == nil {
+		return r, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.resolveNaturalKey")
+	defer func() { exit(err) }()
+
+	var ids []string
+	input := &svcsdk.GetVpcLinksInput{}
+	for {
+		resp, err := rm.sdkapi.GetVpcLinks(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetVpcLinks", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			if aws.ToString(item.Name) == *r.ko.Spec.Name {
+				ids = append(ids, aws.ToString(item.VpcLinkId))
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	id, err := adoption.Unique("VPCLink", fmt.Sprintf("%q", *r.ko.Spec.Name), ids)
+	if err != nil || id == "" {
+		return r, err
+	}
+	r.ko.Status.VPCLinkID = aws.String(id)
+	return r, nil
+}
@@ -14,10 +14,17 @@
 package vpc_link
 
 import (
+	"context"
 	"fmt"
 
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/arn"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/tags"
 )
 
 var (
@@ -27,3 +34,36 @@ var (
 		ackrequeue.DefaultRequeueAfterDuration,
 	)
 )
+
+// syncTags syncs the tags of the VPC link in AWS with its desired tags.
+func (rm *resourceManager) syncTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	desiredTags, _ := convertToOrderedACKTags(desired.ko.Spec.Tags)
+	latestTags, _ := convertToOrderedACKTags(latest.ko.Spec.Tags)
+	return tags.SyncTags(
+		ctx, rm.sdkapi, rm.metrics, rm.cfg.ResourceTagKeys,
+		latest.ko.Status.ACKResourceMetadata.ARN, desiredTags, latestTags,
+	)
+}
+
+// setResourceARN sets the ARN of the VPC link from its ID.
+func (rm *resourceManager) setResourceARN(ko *svcapitypes.VPCLink) {
+	ko.Status.ACKResourceMetadata.ARN = arn.APIGateway(
+		rm.awsPartition, rm.awsRegion, "/vpclinks/%s",
+		ko.Status.VPCLinkID,
+	)
+}
+
+// checkDependents blocks the deletion of the VPC link while Integrations
+// connect through it, which API Gateway would reject with a
+// ConflictException.
+func (rm *resourceManager) checkDependents(ctx context.Context, r *resource) error {
+	kc, err := kubeclient.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return reference.CheckDeletion(ctx, kc, GroupKind.Kind, r.ko, &r.ko.Status.Conditions)
+}
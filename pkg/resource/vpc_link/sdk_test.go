@@ -0,0 +1,156 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package vpc_link
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/smithy-go"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func newVPCLink(name string) *resource {
+	return &resource{&svcapitypes.VPCLink{Spec: svcapitypes.VPCLinkSpec{
+		Name:      aws.String(name),
+		SubnetIDs: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+	}}}
+}
+
+func TestSdk(t *testing.T) {
+	fake.Suite[*resource]{
+		Factory: func() acktypes.AWSResourceManagerFactory { return newResourceManagerFactory() },
+		New:     func(*fake.Fixture) *resource { return newVPCLink("pets") },
+		Missing: func(r *resource) { r.ko.Status.VPCLinkID = aws.String("missing") },
+		Create: []fake.Case[*resource]{
+			{
+				Name: "created",
+				Check: func(t *testing.T, _ *fake.Fixture, r *resource) {
+					if got := aws.ToString(r.ko.Status.VPCLinkStatus); got != "AVAILABLE" {
+						t.Errorf("VPCLinkStatus = %q, want AVAILABLE", got)
+					}
+					wantARN := "arn:aws:apigateway:us-west-2::/vpclinks/" + *r.ko.Status.VPCLinkID
+					if got := string(*r.ko.Status.ACKResourceMetadata.ARN); got != wantARN {
+						t.Errorf("ARN = %q, want %q", got, wantARN)
+					}
+				},
+			},
+			{
+				Name:    "missing subnets",
+				Change:  func(r *resource) { r.ko.Spec.SubnetIDs = nil },
+				WantErr: smithy.InvalidParamsError{},
+			},
+		},
+		Update: []fake.Case[*resource]{
+			{
+				Name:      "name",
+				Change:    func(r *resource) { r.ko.Spec.Name = aws.String("cats") },
+				WantCalls: []string{"UpdateVpcLink"},
+			},
+			{
+				Name:      "tags only",
+				Change:    func(r *resource) { r.ko.Spec.Tags = map[string]*string{"team": aws.String("pets")} },
+				WantCalls: []string{"TagResource"},
+			},
+			{
+				Name:      "subnets changed",
+				Change:    func(r *resource) { r.ko.Spec.SubnetIDs = []*string{aws.String("subnet-3")} },
+				WantErr:   ackerr.Terminal,
+				WantCalls: []string{},
+			},
+		},
+		Delete: []fake.Case[*resource]{
+			{
+				Name: "used by an integration",
+				Setup: func(f *fake.Fixture, r *resource) {
+					if _, err := f.Client.CreateIntegration(context.Background(), &svcsdk.CreateIntegrationInput{
+						ApiId:           aws.String(f.CreateAPI(svcsdktypes.ProtocolTypeHttp)),
+						ConnectionId:    r.ko.Status.VPCLinkID,
+						ConnectionType:  svcsdktypes.ConnectionTypeVpcLink,
+						IntegrationType: svcsdktypes.IntegrationTypeHttpProxy,
+					}); err != nil {
+						f.T.Fatalf("CreateIntegration() error = %v", err)
+					}
+				},
+				WantErr: &svcsdktypes.ConflictException{},
+			},
+		},
+	}.Run(t)
+}
+
+func TestSdkUpdate_Pending(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	latest := f.Create(newVPCLink("pets")).(*resource)
+	latest.ko.Status.VPCLinkStatus = aws.String("PENDING")
+	desired := latest.DeepCopy().(*resource)
+	desired.ko.Spec.Name = aws.String("cats")
+	f.Client.ResetCalls()
+
+	_, err := f.Update(desired, latest)
+	var requeue *ackrequeue.RequeueNeededAfter
+	if !errors.As(err, &requeue) {
+		t.Errorf("Update() error = %v, want a requeue", err)
+	}
+	if calls := f.Client.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %v, want none", calls)
+	}
+}
+
+func TestSdkFind_AdoptedByName(t *testing.T) {
+	f := fake.NewFixture(t, newResourceManagerFactory())
+	existing := f.Create(newVPCLink("pets")).(*resource)
+	f.Create(newVPCLink("orders"))
+	f.Create(newVPCLink("orders"))
+	// List the VPC links over several pages.
+	f.Client.PageSize = 1
+	byFields := func(name string) *resource {
+		return f.Adopt(&resource{&svcapitypes.VPCLink{}}, "adopt", map[string]string{
+			"vpcLinkID": "", "name": name,
+		}).(*resource)
+	}
+
+	tests := []struct {
+		name    string
+		r       *resource
+		wantErr error
+	}{
+		{name: "name in the adoption fields", r: byFields("pets")},
+		{name: "name in the spec", r: f.Adopt(newVPCLink("pets"), "adopt-or-create", nil).(*resource)},
+		{name: "ambiguous name", r: byFields("orders"), wantErr: ackerr.Terminal},
+		{name: "missing name", r: byFields("cats"), wantErr: ackerr.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, err := f.Manager.ReadOne(f.Context, tt.r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadOne() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := aws.ToString(latest.(*resource).ko.Status.VPCLinkID), *existing.ko.Status.VPCLinkID; got != want {
+				t.Errorf("VPCLinkID = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package routekey validates the route keys of HTTP and WebSocket APIs
+// without calling AWS.
+package routekey
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// Default is the catch-all route key of HTTP and WebSocket APIs.
+	Default = "$default"
+	// Connect is the route key used when a client connects to a WebSocket
+	// API.
+	Connect = "$connect"
+	// Disconnect is the route key used when a client disconnects from a
+	// WebSocket API.
+	Disconnect = "$disconnect"
+)
+
+var (
+	// Methods are the HTTP methods supported in the route keys of HTTP APIs.
+	Methods = []string{"ANY", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"}
+
+	pathParameterNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// ValidateHTTP checks that key is a route key of an HTTP API, either
+// "$default" or "METHOD /path" where the path may contain "{param}" segments
+// and end with a greedy "{param+}" segment.
+func ValidateHTTP(fldPath *field.Path, key string) field.ErrorList {
+	switch key {
+	case Default:
+		return nil
+	case Connect, Disconnect:
+		return field.ErrorList{field.Invalid(fldPath, key, "route key is supported only for WebSocket APIs")}
+	}
+	method, path, ok := strings.Cut(key, " ")
+	if !ok {
+		return field.ErrorList{field.Invalid(fldPath, key, `route key must be "$default" or "METHOD /path"`)}
+	}
+	var errs field.ErrorList
+	if !contains(Methods, method) {
+		errs = append(errs, field.NotSupported(fldPath, method, Methods))
+	}
+	return append(errs, ValidatePath(fldPath, path)...)
+}
+
+// ValidateWebSocket checks that key is a route key of a WebSocket API. Apart
+// from "$connect", "$disconnect" and "$default", the keys starting with '$'
+// are reserved by API Gateway.
+func ValidateWebSocket(fldPath *field.Path, key string) field.ErrorList {
+	switch {
+	case key == Default, key == Connect, key == Disconnect:
+		return nil
+	case key == "":
+		return field.ErrorList{field.Required(fldPath, "route key must not be empty")}
+	case strings.HasPrefix(key, "$"):
+		return field.ErrorList{field.NotSupported(fldPath, key, []string{Connect, Disconnect, Default})}
+	}
+	return nil
+}
+
+// ValidatePath checks the path part of a route key. Path parameters must
+// span a whole segment and a greedy path variable can only be the last
+// segment.
+func ValidatePath(fldPath *field.Path, path string) field.ErrorList {
+	var errs field.ErrorList
+	if !strings.HasPrefix(path, "/") {
+		return append(errs, field.Invalid(fldPath, path, "route path must start with '/'"))
+	}
+	if path == "/" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			if i != len(segments)-1 {
+				errs = append(errs, field.Invalid(fldPath, path, "route path must not contain empty segments"))
+			}
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name := strings.TrimSuffix(segment[1:len(segment)-1], "+")
+			if strings.HasSuffix(segment, "+}") && i != len(segments)-1 {
+				errs = append(errs, field.Invalid(fldPath, path, fmt.Sprintf("greedy path variable %q must be the last segment", segment)))
+			}
+			if !pathParameterNameRegex.MatchString(name) {
+				errs = append(errs, field.Invalid(fldPath, path, fmt.Sprintf("invalid path parameter %q", segment)))
+			}
+		case strings.ContainsAny(segment, "{}"):
+			errs = append(errs, field.Invalid(fldPath, path, fmt.Sprintf("path parameter must span the whole segment %q", segment)))
+		}
+	}
+	return errs
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package routekey
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		key           string
+		wantHTTP      bool
+		wantWebSocket bool
+	}{
+		{"$default", true, true},
+		{"GET /pets", true, true},
+		{"ANY /{proxy+}", true, true},
+		{"GET /pets/{petId}/toys", true, true},
+		{"$connect", false, true},
+		{"$disconnect", false, true},
+		{"sendmessage", false, true},
+		{"GET/pets", false, true},
+		{"FETCH /pets", false, true},
+		{"get /pets", false, true},
+		{"GET pets", false, true},
+		{"GET /{proxy+}/toys", false, true},
+		{"GET /pets{id}", false, true},
+		{"$unknown", false, false},
+		{"", false, false},
+	}
+	fldPath := field.NewPath("spec", "routeKey")
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if errs := ValidateHTTP(fldPath, tt.key); (len(errs) == 0) != tt.wantHTTP {
+				t.Errorf("ValidateHTTP(%q) = %v, want valid %v", tt.key, errs, tt.wantHTTP)
+			}
+			if errs := ValidateWebSocket(fldPath, tt.key); (len(errs) == 0) != tt.wantWebSocket {
+				t.Errorf("ValidateWebSocket(%q) = %v, want valid %v", tt.key, errs, tt.wantWebSocket)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"sigs.k8s.io/yaml"
+)
+
+// openAPIMethods maps the operation keys of an OpenAPI path item to route
+// key methods.
+var openAPIMethods = map[string]string{
+	"get":                            "GET",
+	"put":                            "PUT",
+	"post":                           "POST",
+	"delete":                         "DELETE",
+	"patch":                          "PATCH",
+	"head":                           "HEAD",
+	"options":                        "OPTIONS",
+	"x-amazon-apigateway-any-method": "ANY",
+}
+
+func (c *Client) CreateApi(_ context.Context, in *svcsdk.CreateApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateApi"); err != nil {
+		return nil, err
+	}
+	if err := required("Name", in.Name); err != nil {
+		return nil, err
+	}
+	switch in.ProtocolType {
+	case svcsdktypes.ProtocolTypeHttp:
+	case svcsdktypes.ProtocolTypeWebsocket:
+		if in.RouteSelectionExpression == nil {
+			return nil, badRequest("RouteSelectionExpression is required for WEBSOCKET APIs")
+		}
+		if in.Target != nil || in.RouteKey != nil {
+			return nil, badRequest("Quick create is only supported for HTTP APIs")
+		}
+	default:
+		return nil, badRequest("Invalid ProtocolType %q", in.ProtocolType)
+	}
+
+	a := c.newAPI(in.ProtocolType)
+	copyFields(&a.out, in)
+	if in.Target != nil {
+		c.quickCreate(a, in)
+	}
+	out := svcsdk.CreateApiOutput(a.out)
+	return &out, nil
+}
+
+// newAPI adds an API with the defaults set by API Gateway.
+func (c *Client) newAPI(protocolType svcsdktypes.ProtocolType) *api {
+	id := c.newID()
+	scheme := "https"
+	if protocolType == svcsdktypes.ProtocolTypeWebsocket {
+		scheme = "wss"
+	}
+	a := &api{
+		out: svcsdk.GetApiOutput{
+			ApiId:                     aws.String(id),
+			ApiEndpoint:               aws.String(fmt.Sprintf("%s://%s.execute-api.%s.amazonaws.com", scheme, id, c.Region)),
+			ApiKeySelectionExpression: aws.String("$request.header.x-api-key"),
+			CreatedDate:               now(),
+			DisableExecuteApiEndpoint: aws.Bool(false),
+			ProtocolType:              protocolType,
+		},
+		authorizers:  map[string]*svcsdk.GetAuthorizerOutput{},
+		deployments:  map[string]*svcsdk.GetDeploymentOutput{},
+		integrations: map[string]*integration{},
+		models:       map[string]*svcsdk.GetModelOutput{},
+		routes:       map[string]*route{},
+		stages:       map[string]*svcsdk.GetStageOutput{},
+	}
+	if protocolType == svcsdktypes.ProtocolTypeHttp {
+		a.out.RouteSelectionExpression = aws.String("$request.method $request.path")
+	}
+	c.apis[id] = a
+	return a
+}
+
+// quickCreate adds the integration, route and auto-deployed $default stage
+// that API Gateway manages for an API created with a target.
+func (c *Client) quickCreate(a *api, in *svcsdk.CreateApiInput) {
+	integrationType := svcsdktypes.IntegrationTypeHttpProxy
+	payloadFormatVersion := "1.0"
+	if strings.Contains(*in.Target, ":lambda:") {
+		integrationType = svcsdktypes.IntegrationTypeAwsProxy
+		payloadFormatVersion = "2.0"
+	}
+	i := &integration{
+		out: svcsdk.GetIntegrationOutput{
+			ApiGatewayManaged:    aws.Bool(true),
+			ConnectionType:       svcsdktypes.ConnectionTypeInternet,
+			CredentialsArn:       in.CredentialsArn,
+			IntegrationId:        aws.String(c.newID()),
+			IntegrationMethod:    aws.String("POST"),
+			IntegrationType:      integrationType,
+			IntegrationUri:       in.Target,
+			PayloadFormatVersion: aws.String(payloadFormatVersion),
+			TimeoutInMillis:      aws.Int32(30000),
+		},
+		responses: map[string]*svcsdk.GetIntegrationResponseOutput{},
+	}
+	a.integrations[*i.out.IntegrationId] = i
+	routeKey := aws.ToString(in.RouteKey)
+	if routeKey == "" {
+		routeKey = "$default"
+	}
+	c.addRoute(a, routeKey, true).out.Target = aws.String("integrations/" + *i.out.IntegrationId)
+	a.stages["$default"] = &svcsdk.GetStageOutput{
+		ApiGatewayManaged:    aws.Bool(true),
+		AutoDeploy:           aws.Bool(true),
+		CreatedDate:          now(),
+		DefaultRouteSettings: defaultRouteSettings(),
+		LastUpdatedDate:      now(),
+		StageName:            aws.String("$default"),
+	}
+}
+
+func (c *Client) GetApi(_ context.Context, in *svcsdk.GetApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetApi"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	out := a.out
+	return &out, nil
+}
+
+func (c *Client) GetApis(_ context.Context, in *svcsdk.GetApisInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetApisOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetApis"); err != nil {
+		return nil, err
+	}
+	items := make([]svcsdktypes.Api, 0, len(c.apis))
+	for _, id := range sortedKeys(c.apis) {
+		var item svcsdktypes.Api
+		copyFields(&item, c.apis[id].out)
+		items = append(items, item)
+	}
+	items, next, err := page(c, items, in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	return &svcsdk.GetApisOutput{Items: items, NextToken: next}, nil
+}
+
+func (c *Client) UpdateApi(_ context.Context, in *svcsdk.UpdateApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateApi"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(&a.out, in)
+	out := svcsdk.UpdateApiOutput(a.out)
+	return &out, nil
+}
+
+func (c *Client) DeleteApi(_ context.Context, in *svcsdk.DeleteApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteApi"); err != nil {
+		return nil, err
+	}
+	if _, err := c.api(in.ApiId); err != nil {
+		return nil, err
+	}
+	delete(c.apis, *in.ApiId)
+	return &svcsdk.DeleteApiOutput{}, nil
+}
+
+func (c *Client) DeleteCorsConfiguration(_ context.Context, in *svcsdk.DeleteCorsConfigurationInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteCorsConfigurationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteCorsConfiguration"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	a.out.CorsConfiguration = nil
+	return &svcsdk.DeleteCorsConfigurationOutput{}, nil
+}
+
+func (c *Client) ImportApi(_ context.Context, in *svcsdk.ImportApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.ImportApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("ImportApi"); err != nil {
+		return nil, err
+	}
+	def, err := parseDefinition(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	a := c.newAPI(svcsdktypes.ProtocolTypeHttp)
+	c.applyDefinition(a, def)
+	out := svcsdk.ImportApiOutput(a.out)
+	return &out, nil
+}
+
+func (c *Client) ReimportApi(_ context.Context, in *svcsdk.ReimportApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.ReimportApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("ReimportApi"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	def, err := parseDefinition(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	for id, r := range a.routes {
+		if !aws.ToBool(r.out.ApiGatewayManaged) {
+			delete(a.routes, id)
+		}
+	}
+	c.applyDefinition(a, def)
+	out := svcsdk.ReimportApiOutput(a.out)
+	return &out, nil
+}
+
+func (c *Client) ExportApi(_ context.Context, in *svcsdk.ExportApiInput, _ ...func(*svcsdk.Options)) (*svcsdk.ExportApiOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("ExportApi"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToString(in.Specification) != "OAS30" {
+		return nil, badRequest("Invalid Specification %q", aws.ToString(in.Specification))
+	}
+	if a.out.ProtocolType != svcsdktypes.ProtocolTypeHttp {
+		return nil, badRequest("Export is only supported for HTTP APIs")
+	}
+
+	version := aws.ToString(a.out.Version)
+	if in.ExportVersion != nil {
+		version = *in.ExportVersion
+	}
+	paths := map[string]map[string]interface{}{}
+	for _, r := range a.routes {
+		method, path, ok := strings.Cut(aws.ToString(r.out.RouteKey), " ")
+		if !ok {
+			continue
+		}
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		for key, m := range openAPIMethods {
+			if m == method {
+				paths[path][key] = map[string]interface{}{}
+			}
+		}
+	}
+	def := map[string]interface{}{
+		"openapi": "3.0.1",
+		"info":    map[string]interface{}{"title": aws.ToString(a.out.Name), "version": version},
+		"paths":   paths,
+	}
+	var body []byte
+	switch aws.ToString(in.OutputType) {
+	case "JSON":
+		body, err = json.Marshal(def)
+	case "YAML":
+		body, err = yaml.Marshal(def)
+	default:
+		return nil, badRequest("Invalid OutputType %q", aws.ToString(in.OutputType))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &svcsdk.ExportApiOutput{Body: body}, nil
+}
+
+// definition is the part of an OpenAPI definition the fake imports.
+type definition struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths map[string]map[string]interface{} `json:"paths"`
+}
+
+func parseDefinition(body *string) (*definition, error) {
+	if err := required("Body", body); err != nil {
+		return nil, err
+	}
+	def := &definition{}
+	if err := yaml.Unmarshal([]byte(*body), def); err != nil {
+		return nil, badRequest("Invalid OpenAPI definition: %v", err)
+	}
+	if def.Info.Title == "" {
+		return nil, badRequest("Invalid OpenAPI definition: info.title is required")
+	}
+	return def, nil
+}
+
+// applyDefinition sets the name and version of the API and adds a route for
+// each operation of the definition.
+func (c *Client) applyDefinition(a *api, def *definition) {
+	a.out.Name = aws.String(def.Info.Title)
+	if def.Info.Version != "" {
+		a.out.Version = aws.String(def.Info.Version)
+	}
+	paths := make([]string, 0, len(def.Paths))
+	for path := range def.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, key := range sortedKeys(def.Paths[path]) {
+			if method, ok := openAPIMethods[strings.ToLower(key)]; ok {
+				c.addRoute(a, method+" "+path, false)
+			}
+		}
+	}
+}
+
+func (c *Client) TagResource(_ context.Context, in *svcsdk.TagResourceInput, _ ...func(*svcsdk.Options)) (*svcsdk.TagResourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("TagResource"); err != nil {
+		return nil, err
+	}
+	tags, err := c.tags(in.ResourceArn)
+	if err != nil {
+		return nil, err
+	}
+	*tags = mergeTags(*tags, in.Tags)
+	return &svcsdk.TagResourceOutput{}, nil
+}
+
+func (c *Client) UntagResource(_ context.Context, in *svcsdk.UntagResourceInput, _ ...func(*svcsdk.Options)) (*svcsdk.UntagResourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UntagResource"); err != nil {
+		return nil, err
+	}
+	tags, err := c.tags(in.ResourceArn)
+	if err != nil {
+		return nil, err
+	}
+	remaining := mergeTags(*tags, nil)
+	for _, key := range in.TagKeys {
+		delete(remaining, key)
+	}
+	*tags = remaining
+	return &svcsdk.UntagResourceOutput{}, nil
+}
+
+// tags returns the tags of the taggable resource identified by an ARN.
+func (c *Client) tags(arn *string) (*map[string]string, error) {
+	path, err := resourcePath(arn)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "apis":
+		if a, ok := c.apis[parts[1]]; ok {
+			return &a.out.Tags, nil
+		}
+	case len(parts) == 4 && parts[0] == "apis" && parts[2] == "stages":
+		if a, ok := c.apis[parts[1]]; ok {
+			if s, ok := a.stages[parts[3]]; ok {
+				return &s.Tags, nil
+			}
+		}
+	case len(parts) == 2 && parts[0] == "domainnames":
+		if d, ok := c.domainNames[parts[1]]; ok {
+			return &d.out.Tags, nil
+		}
+	case len(parts) == 2 && parts[0] == "vpclinks":
+		if v, ok := c.vpcLinks[parts[1]]; ok {
+			return &v.Tags, nil
+		}
+	}
+	return nil, notFound("Invalid resource identifier specified %s", aws.ToString(arn))
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,877 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+func (c *Client) CreateAuthorizer(_ context.Context, in *svcsdk.CreateAuthorizerInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateAuthorizerOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateAuthorizer"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("Name", in.Name); err != nil {
+		return nil, err
+	}
+	if in.AuthorizerType == "" {
+		return nil, badRequest("AuthorizerType is required")
+	}
+	out := &svcsdk.GetAuthorizerOutput{AuthorizerId: aws.String(c.newID())}
+	copyFields(out, in)
+	a.authorizers[*out.AuthorizerId] = out
+	res := svcsdk.CreateAuthorizerOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetAuthorizer(_ context.Context, in *svcsdk.GetAuthorizerInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetAuthorizerOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetAuthorizer"); err != nil {
+		return nil, err
+	}
+	out, err := c.authorizer(in.ApiId, in.AuthorizerId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateAuthorizer(_ context.Context, in *svcsdk.UpdateAuthorizerInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateAuthorizerOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateAuthorizer"); err != nil {
+		return nil, err
+	}
+	out, err := c.authorizer(in.ApiId, in.AuthorizerId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateAuthorizerOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteAuthorizer(_ context.Context, in *svcsdk.DeleteAuthorizerInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteAuthorizerOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteAuthorizer"); err != nil {
+		return nil, err
+	}
+	if _, err := c.authorizer(in.ApiId, in.AuthorizerId); err != nil {
+		return nil, err
+	}
+	a := c.apis[*in.ApiId]
+	for _, r := range a.routes {
+		if aws.ToString(r.out.AuthorizerId) == *in.AuthorizerId {
+			return nil, conflict("Cannot delete authorizer %s, is referenced in route: %s",
+				*in.AuthorizerId, aws.ToString(r.out.RouteKey))
+		}
+	}
+	delete(a.authorizers, *in.AuthorizerId)
+	return &svcsdk.DeleteAuthorizerOutput{}, nil
+}
+
+func (c *Client) authorizer(apiID, authorizerID *string) (*svcsdk.GetAuthorizerOutput, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := a.authorizers[aws.ToString(authorizerID)]
+	if !ok {
+		return nil, notFound("Invalid authorizer identifier specified %s", aws.ToString(authorizerID))
+	}
+	return out, nil
+}
+
+func (c *Client) CreateDeployment(_ context.Context, in *svcsdk.CreateDeploymentInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateDeploymentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateDeployment"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	var stage *svcsdk.GetStageOutput
+	if in.StageName != nil {
+		if stage = a.stages[*in.StageName]; stage == nil {
+			return nil, notFound("Invalid stage identifier specified %s", *in.StageName)
+		}
+	}
+	out := &svcsdk.GetDeploymentOutput{
+		AutoDeployed:     aws.Bool(false),
+		CreatedDate:      now(),
+		DeploymentId:     aws.String(c.newID()),
+		DeploymentStatus: svcsdktypes.DeploymentStatusDeployed,
+		Description:      in.Description,
+	}
+	a.deployments[*out.DeploymentId] = out
+	if stage != nil {
+		stage.DeploymentId = out.DeploymentId
+		stage.LastUpdatedDate = now()
+	}
+	res := svcsdk.CreateDeploymentOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetDeployment(_ context.Context, in *svcsdk.GetDeploymentInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetDeploymentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetDeployment"); err != nil {
+		return nil, err
+	}
+	out, err := c.deployment(in.ApiId, in.DeploymentId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateDeployment(_ context.Context, in *svcsdk.UpdateDeploymentInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateDeploymentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateDeployment"); err != nil {
+		return nil, err
+	}
+	out, err := c.deployment(in.ApiId, in.DeploymentId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateDeploymentOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteDeployment(_ context.Context, in *svcsdk.DeleteDeploymentInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteDeploymentOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteDeployment"); err != nil {
+		return nil, err
+	}
+	if _, err := c.deployment(in.ApiId, in.DeploymentId); err != nil {
+		return nil, err
+	}
+	a := c.apis[*in.ApiId]
+	for _, s := range a.stages {
+		if aws.ToString(s.DeploymentId) == *in.DeploymentId {
+			return nil, badRequest("Deployment %s is referenced by stage %s",
+				*in.DeploymentId, aws.ToString(s.StageName))
+		}
+	}
+	delete(a.deployments, *in.DeploymentId)
+	return &svcsdk.DeleteDeploymentOutput{}, nil
+}
+
+func (c *Client) deployment(apiID, deploymentID *string) (*svcsdk.GetDeploymentOutput, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := a.deployments[aws.ToString(deploymentID)]
+	if !ok {
+		return nil, notFound("Invalid deployment identifier specified %s", aws.ToString(deploymentID))
+	}
+	return out, nil
+}
+
+func (c *Client) CreateIntegration(_ context.Context, in *svcsdk.CreateIntegrationInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateIntegrationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateIntegration"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if in.IntegrationType == "" {
+		return nil, badRequest("IntegrationType is required")
+	}
+	i := &integration{
+		out: svcsdk.GetIntegrationOutput{
+			ApiGatewayManaged: aws.Bool(false),
+			ConnectionType:    svcsdktypes.ConnectionTypeInternet,
+			IntegrationId:     aws.String(c.newID()),
+		},
+		responses: map[string]*svcsdk.GetIntegrationResponseOutput{},
+	}
+	if a.out.ProtocolType == svcsdktypes.ProtocolTypeHttp {
+		i.out.TimeoutInMillis = aws.Int32(30000)
+	} else {
+		i.out.TimeoutInMillis = aws.Int32(29000)
+		i.out.PassthroughBehavior = svcsdktypes.PassthroughBehaviorWhenNoMatch
+	}
+	copyFields(&i.out, in)
+	if in.TlsConfig != nil {
+		i.out.TlsConfig = &svcsdktypes.TlsConfig{ServerNameToVerify: in.TlsConfig.ServerNameToVerify}
+	}
+	a.integrations[*i.out.IntegrationId] = i
+	res := svcsdk.CreateIntegrationOutput(i.out)
+	return &res, nil
+}
+
+func (c *Client) GetIntegration(_ context.Context, in *svcsdk.GetIntegrationInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetIntegration"); err != nil {
+		return nil, err
+	}
+	i, err := c.integration(in.ApiId, in.IntegrationId)
+	if err != nil {
+		return nil, err
+	}
+	res := i.out
+	return &res, nil
+}
+
+func (c *Client) UpdateIntegration(_ context.Context, in *svcsdk.UpdateIntegrationInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateIntegrationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateIntegration"); err != nil {
+		return nil, err
+	}
+	i, err := c.integration(in.ApiId, in.IntegrationId)
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToBool(i.out.ApiGatewayManaged) {
+		return nil, conflict("Cannot update an integration managed by API Gateway")
+	}
+	copyFields(&i.out, in)
+	if in.TlsConfig != nil {
+		i.out.TlsConfig = &svcsdktypes.TlsConfig{ServerNameToVerify: in.TlsConfig.ServerNameToVerify}
+	}
+	res := svcsdk.UpdateIntegrationOutput(i.out)
+	return &res, nil
+}
+
+func (c *Client) DeleteIntegration(_ context.Context, in *svcsdk.DeleteIntegrationInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteIntegrationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteIntegration"); err != nil {
+		return nil, err
+	}
+	if _, err := c.integration(in.ApiId, in.IntegrationId); err != nil {
+		return nil, err
+	}
+	a := c.apis[*in.ApiId]
+	target := "integrations/" + *in.IntegrationId
+	for _, r := range a.routes {
+		if aws.ToString(r.out.Target) == target {
+			return nil, conflict("Cannot delete integration %s, is referenced in route: %s",
+				*in.IntegrationId, aws.ToString(r.out.RouteKey))
+		}
+	}
+	delete(a.integrations, *in.IntegrationId)
+	return &svcsdk.DeleteIntegrationOutput{}, nil
+}
+
+func (c *Client) integration(apiID, integrationID *string) (*integration, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := a.integrations[aws.ToString(integrationID)]
+	if !ok {
+		return nil, notFound("Invalid integration identifier specified %s", aws.ToString(integrationID))
+	}
+	return i, nil
+}
+
+func (c *Client) CreateIntegrationResponse(_ context.Context, in *svcsdk.CreateIntegrationResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateIntegrationResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateIntegrationResponse"); err != nil {
+		return nil, err
+	}
+	i, err := c.integration(in.ApiId, in.IntegrationId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("IntegrationResponseKey", in.IntegrationResponseKey); err != nil {
+		return nil, err
+	}
+	for _, r := range i.responses {
+		if aws.ToString(r.IntegrationResponseKey) == *in.IntegrationResponseKey {
+			return nil, conflict("Integration response key %s already exists", *in.IntegrationResponseKey)
+		}
+	}
+	out := &svcsdk.GetIntegrationResponseOutput{IntegrationResponseId: aws.String(c.newID())}
+	copyFields(out, in)
+	i.responses[*out.IntegrationResponseId] = out
+	res := svcsdk.CreateIntegrationResponseOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetIntegrationResponse(_ context.Context, in *svcsdk.GetIntegrationResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetIntegrationResponse"); err != nil {
+		return nil, err
+	}
+	out, err := c.integrationResponse(in.ApiId, in.IntegrationId, in.IntegrationResponseId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateIntegrationResponse(_ context.Context, in *svcsdk.UpdateIntegrationResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateIntegrationResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateIntegrationResponse"); err != nil {
+		return nil, err
+	}
+	out, err := c.integrationResponse(in.ApiId, in.IntegrationId, in.IntegrationResponseId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateIntegrationResponseOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteIntegrationResponse(_ context.Context, in *svcsdk.DeleteIntegrationResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteIntegrationResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteIntegrationResponse"); err != nil {
+		return nil, err
+	}
+	if _, err := c.integrationResponse(in.ApiId, in.IntegrationId, in.IntegrationResponseId); err != nil {
+		return nil, err
+	}
+	delete(c.apis[*in.ApiId].integrations[*in.IntegrationId].responses, *in.IntegrationResponseId)
+	return &svcsdk.DeleteIntegrationResponseOutput{}, nil
+}
+
+func (c *Client) integrationResponse(apiID, integrationID, responseID *string) (*svcsdk.GetIntegrationResponseOutput, error) {
+	i, err := c.integration(apiID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := i.responses[aws.ToString(responseID)]
+	if !ok {
+		return nil, notFound("Invalid integration response identifier specified %s", aws.ToString(responseID))
+	}
+	return out, nil
+}
+
+func (c *Client) CreateModel(_ context.Context, in *svcsdk.CreateModelInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateModelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateModel"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("Name", in.Name); err != nil {
+		return nil, err
+	}
+	if err := required("Schema", in.Schema); err != nil {
+		return nil, err
+	}
+	for _, m := range a.models {
+		if aws.ToString(m.Name) == *in.Name {
+			return nil, conflict("Model name %s already exists", *in.Name)
+		}
+	}
+	out := &svcsdk.GetModelOutput{ModelId: aws.String(c.newID())}
+	copyFields(out, in)
+	a.models[*out.ModelId] = out
+	res := svcsdk.CreateModelOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetModel(_ context.Context, in *svcsdk.GetModelInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetModelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetModel"); err != nil {
+		return nil, err
+	}
+	out, err := c.model(in.ApiId, in.ModelId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateModel(_ context.Context, in *svcsdk.UpdateModelInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateModelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateModel"); err != nil {
+		return nil, err
+	}
+	out, err := c.model(in.ApiId, in.ModelId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateModelOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteModel(_ context.Context, in *svcsdk.DeleteModelInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteModelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteModel"); err != nil {
+		return nil, err
+	}
+	if _, err := c.model(in.ApiId, in.ModelId); err != nil {
+		return nil, err
+	}
+	delete(c.apis[*in.ApiId].models, *in.ModelId)
+	return &svcsdk.DeleteModelOutput{}, nil
+}
+
+func (c *Client) model(apiID, modelID *string) (*svcsdk.GetModelOutput, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := a.models[aws.ToString(modelID)]
+	if !ok {
+		return nil, notFound("Invalid model identifier specified %s", aws.ToString(modelID))
+	}
+	return out, nil
+}
+
+// addRoute adds a route with the given key to an API. Callers check that the
+// key is not taken.
+func (c *Client) addRoute(a *api, routeKey string, managed bool) *route {
+	r := &route{
+		out: svcsdk.GetRouteOutput{
+			ApiGatewayManaged: aws.Bool(managed),
+			ApiKeyRequired:    aws.Bool(false),
+			AuthorizationType: svcsdktypes.AuthorizationTypeNone,
+			RouteId:           aws.String(c.newID()),
+			RouteKey:          aws.String(routeKey),
+		},
+		responses: map[string]*svcsdk.GetRouteResponseOutput{},
+	}
+	a.routes[*r.out.RouteId] = r
+	return r
+}
+
+// checkRoute returns an error if the route key is taken by another route
+// than routeID or if the target or authorizer of the route do not exist.
+func checkRoute(a *api, routeID string, routeKey, target, authorizerID *string) error {
+	if routeKey != nil {
+		for id, r := range a.routes {
+			if id != routeID && aws.ToString(r.out.RouteKey) == *routeKey {
+				return conflict("Unable to create route %s. The route already exists", *routeKey)
+			}
+		}
+	}
+	if target != nil {
+		id, ok := strings.CutPrefix(*target, "integrations/")
+		if !ok || a.integrations[id] == nil {
+			return badRequest("Invalid integration target %s", *target)
+		}
+	}
+	if authorizerID != nil && a.authorizers[*authorizerID] == nil {
+		return badRequest("Invalid authorizer ID %s", *authorizerID)
+	}
+	return nil
+}
+
+func (c *Client) CreateRoute(_ context.Context, in *svcsdk.CreateRouteInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateRouteOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateRoute"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("RouteKey", in.RouteKey); err != nil {
+		return nil, err
+	}
+	if err := checkRoute(a, "", in.RouteKey, in.Target, in.AuthorizerId); err != nil {
+		return nil, err
+	}
+	r := c.addRoute(a, *in.RouteKey, false)
+	copyFields(&r.out, in)
+	res := svcsdk.CreateRouteOutput(r.out)
+	return &res, nil
+}
+
+func (c *Client) GetRoute(_ context.Context, in *svcsdk.GetRouteInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetRouteOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetRoute"); err != nil {
+		return nil, err
+	}
+	r, err := c.route(in.ApiId, in.RouteId)
+	if err != nil {
+		return nil, err
+	}
+	res := r.out
+	return &res, nil
+}
+
+func (c *Client) GetRoutes(_ context.Context, in *svcsdk.GetRoutesInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetRoutesOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetRoutes"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]svcsdktypes.Route, 0, len(a.routes))
+	for _, id := range sortedKeys(a.routes) {
+		var item svcsdktypes.Route
+		copyFields(&item, a.routes[id].out)
+		items = append(items, item)
+	}
+	items, next, err := page(c, items, in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	return &svcsdk.GetRoutesOutput{Items: items, NextToken: next}, nil
+}
+
+func (c *Client) UpdateRoute(_ context.Context, in *svcsdk.UpdateRouteInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateRouteOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateRoute"); err != nil {
+		return nil, err
+	}
+	r, err := c.route(in.ApiId, in.RouteId)
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToBool(r.out.ApiGatewayManaged) {
+		return nil, conflict("Cannot update a route managed by API Gateway")
+	}
+	if err := checkRoute(c.apis[*in.ApiId], *in.RouteId, in.RouteKey, in.Target, in.AuthorizerId); err != nil {
+		return nil, err
+	}
+	params := r.out.RequestParameters
+	copyFields(&r.out, in)
+	if in.RequestParameters != nil {
+		// Request parameters are added or updated, they are only removed
+		// with DeleteRouteRequestParameter.
+		merged := make(map[string]svcsdktypes.ParameterConstraints, len(params)+len(in.RequestParameters))
+		for k, v := range params {
+			merged[k] = v
+		}
+		for k, v := range in.RequestParameters {
+			merged[k] = v
+		}
+		r.out.RequestParameters = merged
+	}
+	res := svcsdk.UpdateRouteOutput(r.out)
+	return &res, nil
+}
+
+func (c *Client) DeleteRoute(_ context.Context, in *svcsdk.DeleteRouteInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteRoute"); err != nil {
+		return nil, err
+	}
+	if _, err := c.route(in.ApiId, in.RouteId); err != nil {
+		return nil, err
+	}
+	delete(c.apis[*in.ApiId].routes, *in.RouteId)
+	return &svcsdk.DeleteRouteOutput{}, nil
+}
+
+func (c *Client) DeleteRouteRequestParameter(_ context.Context, in *svcsdk.DeleteRouteRequestParameterInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteRequestParameterOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteRouteRequestParameter"); err != nil {
+		return nil, err
+	}
+	r, err := c.route(in.ApiId, in.RouteId)
+	if err != nil {
+		return nil, err
+	}
+	key := aws.ToString(in.RequestParameterKey)
+	if _, ok := r.out.RequestParameters[key]; !ok {
+		return nil, notFound("Invalid request parameter key specified %s", key)
+	}
+	r.out.RequestParameters = without(r.out.RequestParameters, key)
+	return &svcsdk.DeleteRouteRequestParameterOutput{}, nil
+}
+
+func (c *Client) route(apiID, routeID *string) (*route, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := a.routes[aws.ToString(routeID)]
+	if !ok {
+		return nil, notFound("Invalid route identifier specified %s", aws.ToString(routeID))
+	}
+	return r, nil
+}
+
+func (c *Client) CreateRouteResponse(_ context.Context, in *svcsdk.CreateRouteResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateRouteResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateRouteResponse"); err != nil {
+		return nil, err
+	}
+	r, err := c.route(in.ApiId, in.RouteId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("RouteResponseKey", in.RouteResponseKey); err != nil {
+		return nil, err
+	}
+	for _, rr := range r.responses {
+		if aws.ToString(rr.RouteResponseKey) == *in.RouteResponseKey {
+			return nil, conflict("Route response key %s already exists", *in.RouteResponseKey)
+		}
+	}
+	out := &svcsdk.GetRouteResponseOutput{RouteResponseId: aws.String(c.newID())}
+	copyFields(out, in)
+	r.responses[*out.RouteResponseId] = out
+	res := svcsdk.CreateRouteResponseOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetRouteResponse(_ context.Context, in *svcsdk.GetRouteResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetRouteResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetRouteResponse"); err != nil {
+		return nil, err
+	}
+	out, err := c.routeResponse(in.ApiId, in.RouteId, in.RouteResponseId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateRouteResponse(_ context.Context, in *svcsdk.UpdateRouteResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateRouteResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateRouteResponse"); err != nil {
+		return nil, err
+	}
+	out, err := c.routeResponse(in.ApiId, in.RouteId, in.RouteResponseId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateRouteResponseOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteRouteResponse(_ context.Context, in *svcsdk.DeleteRouteResponseInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteResponseOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteRouteResponse"); err != nil {
+		return nil, err
+	}
+	if _, err := c.routeResponse(in.ApiId, in.RouteId, in.RouteResponseId); err != nil {
+		return nil, err
+	}
+	delete(c.apis[*in.ApiId].routes[*in.RouteId].responses, *in.RouteResponseId)
+	return &svcsdk.DeleteRouteResponseOutput{}, nil
+}
+
+func (c *Client) routeResponse(apiID, routeID, responseID *string) (*svcsdk.GetRouteResponseOutput, error) {
+	r, err := c.route(apiID, routeID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := r.responses[aws.ToString(responseID)]
+	if !ok {
+		return nil, notFound("Invalid route response identifier specified %s", aws.ToString(responseID))
+	}
+	return out, nil
+}
+
+// defaultRouteSettings returns the default route settings API Gateway sets
+// on new stages.
+func defaultRouteSettings() *svcsdktypes.RouteSettings {
+	return &svcsdktypes.RouteSettings{DetailedMetricsEnabled: aws.Bool(false)}
+}
+
+// withRouteSettings returns a copy of the route settings of a stage with the
+// given settings added or updated. Like API Gateway, the fake reports the
+// defaults of the settings that were not set.
+func withRouteSettings(
+	current map[string]svcsdktypes.RouteSettings,
+	added map[string]svcsdktypes.RouteSettings,
+) map[string]svcsdktypes.RouteSettings {
+	merged := make(map[string]svcsdktypes.RouteSettings, len(current)+len(added))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range added {
+		if v.DetailedMetricsEnabled == nil {
+			v.DetailedMetricsEnabled = aws.Bool(false)
+		}
+		if v.LoggingLevel == "" {
+			v.LoggingLevel = svcsdktypes.LoggingLevelOff
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+func (c *Client) CreateStage(_ context.Context, in *svcsdk.CreateStageInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateStageOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateStage"); err != nil {
+		return nil, err
+	}
+	a, err := c.api(in.ApiId)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("StageName", in.StageName); err != nil {
+		return nil, err
+	}
+	if a.stages[*in.StageName] != nil {
+		return nil, conflict("Stage already exist")
+	}
+	if in.DeploymentId != nil && a.deployments[*in.DeploymentId] == nil {
+		return nil, badRequest("Invalid deployment identifier specified %s", *in.DeploymentId)
+	}
+	out := &svcsdk.GetStageOutput{
+		ApiGatewayManaged:    aws.Bool(false),
+		AutoDeploy:           aws.Bool(false),
+		CreatedDate:          now(),
+		DefaultRouteSettings: defaultRouteSettings(),
+		LastUpdatedDate:      now(),
+	}
+	copyFields(out, in)
+	if in.RouteSettings != nil {
+		out.RouteSettings = withRouteSettings(nil, in.RouteSettings)
+	}
+	a.stages[*out.StageName] = out
+	res := svcsdk.CreateStageOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetStage(_ context.Context, in *svcsdk.GetStageInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetStageOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetStage"); err != nil {
+		return nil, err
+	}
+	out, err := c.stage(in.ApiId, in.StageName)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateStage(_ context.Context, in *svcsdk.UpdateStageInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateStageOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateStage"); err != nil {
+		return nil, err
+	}
+	out, err := c.stage(in.ApiId, in.StageName)
+	if err != nil {
+		return nil, err
+	}
+	if in.DeploymentId != nil && c.apis[*in.ApiId].deployments[*in.DeploymentId] == nil {
+		return nil, badRequest("Invalid deployment identifier specified %s", *in.DeploymentId)
+	}
+	routeSettings := out.RouteSettings
+	copyFields(out, in)
+	if in.RouteSettings != nil {
+		// Route settings are added or updated, they are only removed with
+		// DeleteRouteSettings.
+		out.RouteSettings = withRouteSettings(routeSettings, in.RouteSettings)
+	}
+	out.LastUpdatedDate = now()
+	res := svcsdk.UpdateStageOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteStage(_ context.Context, in *svcsdk.DeleteStageInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteStageOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteStage"); err != nil {
+		return nil, err
+	}
+	if _, err := c.stage(in.ApiId, in.StageName); err != nil {
+		return nil, err
+	}
+	delete(c.apis[*in.ApiId].stages, *in.StageName)
+	return &svcsdk.DeleteStageOutput{}, nil
+}
+
+func (c *Client) DeleteRouteSettings(_ context.Context, in *svcsdk.DeleteRouteSettingsInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteSettingsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteRouteSettings"); err != nil {
+		return nil, err
+	}
+	out, err := c.stage(in.ApiId, in.StageName)
+	if err != nil {
+		return nil, err
+	}
+	key := aws.ToString(in.RouteKey)
+	if _, ok := out.RouteSettings[key]; !ok {
+		return nil, notFound("Unable to find route settings for route key %s", key)
+	}
+	out.RouteSettings = without(out.RouteSettings, key)
+	return &svcsdk.DeleteRouteSettingsOutput{}, nil
+}
+
+func (c *Client) stage(apiID, stageName *string) (*svcsdk.GetStageOutput, error) {
+	a, err := c.api(apiID)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := a.stages[aws.ToString(stageName)]
+	if !ok {
+		return nil, notFound("Invalid stage identifier specified %s", aws.ToString(stageName))
+	}
+	return out, nil
+}
+
+// without returns a copy of m without the key, so that the maps returned by
+// earlier calls are left untouched.
+func without[T any](m map[string]T, key string) map[string]T {
+	res := make(map[string]T, len(m))
+	for k, v := range m {
+		if k != key {
+			res[k] = v
+		}
+	}
+	return res
+}
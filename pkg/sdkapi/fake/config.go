@@ -0,0 +1,66 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Config returns an aws-sdk-go-v2 configuration whose API Gateway v2 clients
+// are served by c instead of AWS. The calls go through the input validation
+// and error wrapping of the SDK, and are answered before anything is signed
+// or sent. Calls to other services are left untouched.
+func (c *Client) Config() aws.Config {
+	return aws.Config{
+		Region: c.Region,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		RetryMaxAttempts: 1,
+		APIOptions:       []func(*middleware.Stack) error{c.addServeMiddleware},
+	}
+}
+
+// addServeMiddleware answers the API Gateway v2 calls with the method of c
+// named after the operation, once the input is validated.
+func (c *Client) addServeMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(
+		"fake.Serve",
+		func(
+			ctx context.Context,
+			in middleware.InitializeInput,
+			next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if awsmiddleware.GetServiceID(ctx) != svcsdk.ServiceID {
+				return next.HandleInitialize(ctx, in)
+			}
+			op := awsmiddleware.GetOperationName(ctx)
+			method := reflect.ValueOf(c).MethodByName(op)
+			if !method.IsValid() {
+				return middleware.InitializeOutput{}, middleware.Metadata{},
+					fmt.Errorf("fake: operation %s is not implemented", op)
+			}
+			res := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(in.Parameters)})
+			err, _ := res[1].Interface().(error)
+			return middleware.InitializeOutput{Result: res[0].Interface()}, middleware.Metadata{}, err
+		},
+	), middleware.After)
+}
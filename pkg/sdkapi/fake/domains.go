@@ -0,0 +1,351 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+// regionalHostedZoneID is the hosted zone of the regional API Gateway
+// domain names reported by the fake.
+const regionalHostedZoneID = "Z2OJLYMUO9EFXC"
+
+func (c *Client) CreateDomainName(_ context.Context, in *svcsdk.CreateDomainNameInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateDomainNameOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateDomainName"); err != nil {
+		return nil, err
+	}
+	if err := required("DomainName", in.DomainName); err != nil {
+		return nil, err
+	}
+	if c.domainNames[*in.DomainName] != nil {
+		return nil, conflict("The domain name you provided already exists.")
+	}
+	d := &domainName{
+		out: svcsdk.GetDomainNameOutput{
+			ApiMappingSelectionExpression: aws.String("$request.basepath"),
+			DomainName:                    in.DomainName,
+			Tags:                          in.Tags,
+		},
+		mappings: map[string]*svcsdk.GetApiMappingOutput{},
+	}
+	d.out.DomainNameConfigurations = c.domainNameConfigurations(in.DomainNameConfigurations)
+	d.out.MutualTlsAuthentication = mutualTLS(in.MutualTlsAuthentication)
+	c.domainNames[*in.DomainName] = d
+	res := svcsdk.CreateDomainNameOutput(d.out)
+	return &res, nil
+}
+
+func (c *Client) GetDomainName(_ context.Context, in *svcsdk.GetDomainNameInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetDomainNameOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetDomainName"); err != nil {
+		return nil, err
+	}
+	d, err := c.domainName(in.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	res := d.out
+	return &res, nil
+}
+
+func (c *Client) UpdateDomainName(_ context.Context, in *svcsdk.UpdateDomainNameInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateDomainNameOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateDomainName"); err != nil {
+		return nil, err
+	}
+	d, err := c.domainName(in.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	if in.DomainNameConfigurations != nil {
+		d.out.DomainNameConfigurations = c.domainNameConfigurations(in.DomainNameConfigurations)
+	}
+	if in.MutualTlsAuthentication != nil {
+		d.out.MutualTlsAuthentication = mutualTLS(in.MutualTlsAuthentication)
+	}
+	res := svcsdk.UpdateDomainNameOutput(d.out)
+	return &res, nil
+}
+
+func (c *Client) DeleteDomainName(_ context.Context, in *svcsdk.DeleteDomainNameInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteDomainNameOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteDomainName"); err != nil {
+		return nil, err
+	}
+	if _, err := c.domainName(in.DomainName); err != nil {
+		return nil, err
+	}
+	delete(c.domainNames, *in.DomainName)
+	return &svcsdk.DeleteDomainNameOutput{}, nil
+}
+
+func (c *Client) domainName(name *string) (*domainName, error) {
+	if err := required("DomainName", name); err != nil {
+		return nil, err
+	}
+	d, ok := c.domainNames[*name]
+	if !ok {
+		return nil, notFound("Invalid domain name identifier specified %s", *name)
+	}
+	return d, nil
+}
+
+// domainNameConfigurations returns the configurations with the fields set by
+// API Gateway for an available regional endpoint.
+func (c *Client) domainNameConfigurations(in []svcsdktypes.DomainNameConfiguration) []svcsdktypes.DomainNameConfiguration {
+	var res []svcsdktypes.DomainNameConfiguration
+	for _, cfg := range in {
+		cfg.ApiGatewayDomainName = aws.String(fmt.Sprintf("d-%s.execute-api.%s.amazonaws.com", c.newID(), c.Region))
+		cfg.DomainNameStatus = svcsdktypes.DomainNameStatusAvailable
+		cfg.HostedZoneId = aws.String(regionalHostedZoneID)
+		if cfg.EndpointType == "" {
+			cfg.EndpointType = svcsdktypes.EndpointTypeRegional
+		}
+		if cfg.SecurityPolicy == "" {
+			cfg.SecurityPolicy = svcsdktypes.SecurityPolicyTls12
+		}
+		res = append(res, cfg)
+	}
+	return res
+}
+
+func mutualTLS(in *svcsdktypes.MutualTlsAuthenticationInput) *svcsdktypes.MutualTlsAuthentication {
+	if in == nil {
+		return nil
+	}
+	return &svcsdktypes.MutualTlsAuthentication{
+		TruststoreUri:     in.TruststoreUri,
+		TruststoreVersion: in.TruststoreVersion,
+	}
+}
+
+func (c *Client) CreateApiMapping(_ context.Context, in *svcsdk.CreateApiMappingInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateApiMappingOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateApiMapping"); err != nil {
+		return nil, err
+	}
+	d, err := c.domainName(in.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkApiMapping(d, "", in.ApiId, in.Stage, in.ApiMappingKey); err != nil {
+		return nil, err
+	}
+	out := &svcsdk.GetApiMappingOutput{ApiMappingId: aws.String(c.newID()), ApiMappingKey: aws.String("")}
+	copyFields(out, in)
+	d.mappings[*out.ApiMappingId] = out
+	res := svcsdk.CreateApiMappingOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetApiMapping(_ context.Context, in *svcsdk.GetApiMappingInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetApiMappingOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetApiMapping"); err != nil {
+		return nil, err
+	}
+	out, err := c.apiMapping(in.DomainName, in.ApiMappingId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) UpdateApiMapping(_ context.Context, in *svcsdk.UpdateApiMappingInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateApiMappingOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateApiMapping"); err != nil {
+		return nil, err
+	}
+	out, err := c.apiMapping(in.DomainName, in.ApiMappingId)
+	if err != nil {
+		return nil, err
+	}
+	apiID, stage := in.ApiId, in.Stage
+	if stage == nil {
+		stage = out.Stage
+	}
+	d := c.domainNames[*in.DomainName]
+	if err := c.checkApiMapping(d, *in.ApiMappingId, apiID, stage, in.ApiMappingKey); err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateApiMappingOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteApiMapping(_ context.Context, in *svcsdk.DeleteApiMappingInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteApiMappingOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteApiMapping"); err != nil {
+		return nil, err
+	}
+	if _, err := c.apiMapping(in.DomainName, in.ApiMappingId); err != nil {
+		return nil, err
+	}
+	delete(c.domainNames[*in.DomainName].mappings, *in.ApiMappingId)
+	return &svcsdk.DeleteApiMappingOutput{}, nil
+}
+
+// checkApiMapping returns an error if the stage of the API does not exist or
+// if the mapping key is taken by another mapping than mappingID.
+func (c *Client) checkApiMapping(d *domainName, mappingID string, apiID, stage, key *string) error {
+	a, err := c.api(apiID)
+	if err != nil {
+		return err
+	}
+	if err := required("Stage", stage); err != nil {
+		return err
+	}
+	if a.stages[*stage] == nil {
+		return badRequest("Invalid stage identifier specified %s", *stage)
+	}
+	if key == nil {
+		return nil
+	}
+	for id, m := range d.mappings {
+		if id != mappingID && aws.ToString(m.ApiMappingKey) == *key {
+			return conflict("ApiMapping key %q already exists for domain name %s", *key, aws.ToString(d.out.DomainName))
+		}
+	}
+	return nil
+}
+
+func (c *Client) apiMapping(domain, mappingID *string) (*svcsdk.GetApiMappingOutput, error) {
+	d, err := c.domainName(domain)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := d.mappings[aws.ToString(mappingID)]
+	if !ok {
+		return nil, notFound("Invalid API mapping identifier specified %s", aws.ToString(mappingID))
+	}
+	return out, nil
+}
+
+func (c *Client) CreateVpcLink(_ context.Context, in *svcsdk.CreateVpcLinkInput, _ ...func(*svcsdk.Options)) (*svcsdk.CreateVpcLinkOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("CreateVpcLink"); err != nil {
+		return nil, err
+	}
+	if err := required("Name", in.Name); err != nil {
+		return nil, err
+	}
+	if len(in.SubnetIds) == 0 {
+		return nil, badRequest("SubnetIds is required")
+	}
+	out := &svcsdk.GetVpcLinkOutput{
+		CreatedDate:    now(),
+		VpcLinkId:      aws.String(c.newID()),
+		VpcLinkStatus:  svcsdktypes.VpcLinkStatusAvailable,
+		VpcLinkVersion: svcsdktypes.VpcLinkVersionV2,
+	}
+	copyFields(out, in)
+	c.vpcLinks[*out.VpcLinkId] = out
+	res := svcsdk.CreateVpcLinkOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) GetVpcLink(_ context.Context, in *svcsdk.GetVpcLinkInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinkOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetVpcLink"); err != nil {
+		return nil, err
+	}
+	out, err := c.vpcLink(in.VpcLinkId)
+	if err != nil {
+		return nil, err
+	}
+	res := *out
+	return &res, nil
+}
+
+func (c *Client) GetVpcLinks(_ context.Context, in *svcsdk.GetVpcLinksInput, _ ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinksOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("GetVpcLinks"); err != nil {
+		return nil, err
+	}
+	items := make([]svcsdktypes.VpcLink, 0, len(c.vpcLinks))
+	for _, id := range sortedKeys(c.vpcLinks) {
+		var item svcsdktypes.VpcLink
+		copyFields(&item, c.vpcLinks[id])
+		items = append(items, item)
+	}
+	items, next, err := page(c, items, in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	return &svcsdk.GetVpcLinksOutput{Items: items, NextToken: next}, nil
+}
+
+func (c *Client) UpdateVpcLink(_ context.Context, in *svcsdk.UpdateVpcLinkInput, _ ...func(*svcsdk.Options)) (*svcsdk.UpdateVpcLinkOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("UpdateVpcLink"); err != nil {
+		return nil, err
+	}
+	out, err := c.vpcLink(in.VpcLinkId)
+	if err != nil {
+		return nil, err
+	}
+	copyFields(out, in)
+	res := svcsdk.UpdateVpcLinkOutput(*out)
+	return &res, nil
+}
+
+func (c *Client) DeleteVpcLink(_ context.Context, in *svcsdk.DeleteVpcLinkInput, _ ...func(*svcsdk.Options)) (*svcsdk.DeleteVpcLinkOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call("DeleteVpcLink"); err != nil {
+		return nil, err
+	}
+	if _, err := c.vpcLink(in.VpcLinkId); err != nil {
+		return nil, err
+	}
+	for _, a := range c.apis {
+		for _, i := range a.integrations {
+			if i.out.ConnectionType == svcsdktypes.ConnectionTypeVpcLink && aws.ToString(i.out.ConnectionId) == *in.VpcLinkId {
+				return nil, conflict("Cannot delete vpc link %s, is referenced by integration %s",
+					*in.VpcLinkId, aws.ToString(i.out.IntegrationId))
+			}
+		}
+	}
+	delete(c.vpcLinks, *in.VpcLinkId)
+	return &svcsdk.DeleteVpcLinkOutput{}, nil
+}
+
+func (c *Client) vpcLink(vpcLinkID *string) (*svcsdk.GetVpcLinkOutput, error) {
+	if err := required("VpcLinkId", vpcLinkID); err != nil {
+		return nil, err
+	}
+	out, ok := c.vpcLinks[*vpcLinkID]
+	if !ok {
+		return nil, notFound("Invalid VPC link identifier specified %s", *vpcLinkID)
+	}
+	return out, nil
+}
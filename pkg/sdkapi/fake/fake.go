@@ -0,0 +1,240 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package fake provides a stateful in-memory implementation of the API
+// Gateway v2 client for unit tests. It keeps APIs and their authorizers,
+// deployments, integrations, models, routes and stages, as well as custom
+// domain names, API mappings and VPC links, and mimics the API Gateway
+// behaviors the resource managers rely on: generated IDs and defaults,
+// NotFoundException for missing resources, ConflictException for duplicate
+// keys and partial updates that leave unset fields untouched.
+package fake
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi"
+)
+
+var _ sdkapi.Client = (*Client)(nil)
+
+// Client is an in-memory API Gateway v2 control plane. The zero value is not
+// usable, create one with New.
+type Client struct {
+	// Region is the region in the endpoints of the APIs.
+	Region string
+	// PageSize limits the number of items returned by the list operations
+	// when positive, to exercise pagination.
+	PageSize int
+
+	mu          sync.Mutex
+	calls       []string
+	failures    map[string]error
+	lastID      int64
+	apis        map[string]*api
+	domainNames map[string]*domainName
+	vpcLinks    map[string]*svcsdk.GetVpcLinkOutput
+}
+
+// api holds an API with the resources that belong to it.
+type api struct {
+	out          svcsdk.GetApiOutput
+	authorizers  map[string]*svcsdk.GetAuthorizerOutput
+	deployments  map[string]*svcsdk.GetDeploymentOutput
+	integrations map[string]*integration
+	models       map[string]*svcsdk.GetModelOutput
+	routes       map[string]*route
+	stages       map[string]*svcsdk.GetStageOutput
+}
+
+type integration struct {
+	out       svcsdk.GetIntegrationOutput
+	responses map[string]*svcsdk.GetIntegrationResponseOutput
+}
+
+type route struct {
+	out       svcsdk.GetRouteOutput
+	responses map[string]*svcsdk.GetRouteResponseOutput
+}
+
+type domainName struct {
+	out      svcsdk.GetDomainNameOutput
+	mappings map[string]*svcsdk.GetApiMappingOutput
+}
+
+// New returns an empty control plane for the us-west-2 region.
+func New() *Client {
+	return &Client{
+		Region:      "us-west-2",
+		failures:    map[string]error{},
+		apis:        map[string]*api{},
+		domainNames: map[string]*domainName{},
+		vpcLinks:    map[string]*svcsdk.GetVpcLinkOutput{},
+	}
+}
+
+// Fail makes the next call of the operation, e.g. "UpdateRoute", return err
+// without changing any state.
+func (c *Client) Fail(op string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[op] = err
+}
+
+// Calls returns the operations called so far, in order.
+func (c *Client) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+// ResetCalls forgets the operations called so far.
+func (c *Client) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// call records a call of op and returns the failure registered for it.
+// Callers hold c.mu.
+func (c *Client) call(op string) error {
+	c.calls = append(c.calls, op)
+	if err, ok := c.failures[op]; ok {
+		delete(c.failures, op)
+		return err
+	}
+	return nil
+}
+
+// newID returns a new identifier in the format of the API Gateway ones, ten
+// lowercase alphanumeric characters.
+func (c *Client) newID() string {
+	c.lastID++
+	return fmt.Sprintf("%010s", strconv.FormatInt(c.lastID, 36))
+}
+
+func (c *Client) api(apiID *string) (*api, error) {
+	if apiID == nil {
+		return nil, badRequest("ApiId is required")
+	}
+	a, ok := c.apis[*apiID]
+	if !ok {
+		return nil, notFound("Invalid API identifier specified %s", *apiID)
+	}
+	return a, nil
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &svcsdktypes.NotFoundException{Message: aws.String(fmt.Sprintf(format, args...))}
+}
+
+func conflict(format string, args ...interface{}) error {
+	return &svcsdktypes.ConflictException{Message: aws.String(fmt.Sprintf(format, args...))}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &svcsdktypes.BadRequestException{Message: aws.String(fmt.Sprintf(format, args...))}
+}
+
+// required returns a BadRequestException if the value of a required input
+// field is not set.
+func required(name string, v *string) error {
+	if v == nil || *v == "" {
+		return badRequest("%s is required", name)
+	}
+	return nil
+}
+
+// copyFields sets the fields of the struct pointed to by dst to the values
+// of the fields of the struct src with the same name and type, skipping the
+// unset ones. Input shapes are applied to the stored output shapes this way,
+// which gives the partial update semantics of the Update operations.
+func copyFields(dst interface{}, src interface{}) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Ptr {
+		sv = sv.Elem()
+	}
+	for i := 0; i < sv.NumField(); i++ {
+		sf := sv.Type().Field(i)
+		if !sf.IsExported() || sv.Field(i).IsZero() {
+			continue
+		}
+		df := dv.FieldByName(sf.Name)
+		if !df.IsValid() || df.Type() != sf.Type {
+			continue
+		}
+		df.Set(sv.Field(i))
+	}
+}
+
+// page returns the items of the page starting at the token, along with the
+// token of the next page.
+func page[T any](c *Client, items []T, token *string) ([]T, *string, error) {
+	start := 0
+	if token != nil {
+		var err error
+		if start, err = strconv.Atoi(*token); err != nil || start > len(items) {
+			return nil, nil, badRequest("Invalid NextToken %s", *token)
+		}
+	}
+	end := len(items)
+	if c.PageSize > 0 && start+c.PageSize < end {
+		end = start + c.PageSize
+	}
+	var next *string
+	if end < len(items) {
+		next = aws.String(strconv.Itoa(end))
+	}
+	return items[start:end], next, nil
+}
+
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Second)
+	return &t
+}
+
+// resourcePath returns the path of the resource identified by an API Gateway
+// ARN, e.g. "/apis/a1b2c3d4e5".
+func resourcePath(arn *string) (string, error) {
+	if arn == nil {
+		return "", badRequest("ResourceArn is required")
+	}
+	i := strings.Index(*arn, "::/")
+	if !strings.HasPrefix(*arn, "arn:") || !strings.Contains(*arn, ":apigateway:") || i < 0 {
+		return "", badRequest("Invalid resource ARN %s", *arn)
+	}
+	return (*arn)[i+2:], nil
+}
+
+// mergeTags returns a copy of tags with the added tags, so that the maps
+// returned by earlier calls are left untouched.
+func mergeTags(tags map[string]string, added map[string]string) map[string]string {
+	merged := make(map[string]string, len(tags)+len(added))
+	for k, v := range tags {
+		merged[k] = v
+	}
+	for k, v := range added {
+		merged[k] = v
+	}
+	return merged
+}
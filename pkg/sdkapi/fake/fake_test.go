@@ -0,0 +1,280 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+func newHTTPAPI(t *testing.T, c *Client) string {
+	t.Helper()
+	out, err := c.CreateApi(context.Background(), &svcsdk.CreateApiInput{
+		Name:         aws.String("test"),
+		ProtocolType: svcsdktypes.ProtocolTypeHttp,
+	})
+	if err != nil {
+		t.Fatalf("CreateApi() error = %v", err)
+	}
+	return *out.ApiId
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	apiID := newHTTPAPI(t, c)
+	if _, err := c.CreateRoute(ctx, &svcsdk.CreateRouteInput{ApiId: &apiID, RouteKey: aws.String("GET /pets")}); err != nil {
+		t.Fatalf("CreateRoute() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		call func() error
+		want interface{}
+	}{
+		{
+			name: "missing API",
+			call: func() error {
+				_, err := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: aws.String("missing")})
+				return err
+			},
+			want: &svcsdktypes.NotFoundException{},
+		},
+		{
+			name: "duplicate route key",
+			call: func() error {
+				_, err := c.CreateRoute(ctx, &svcsdk.CreateRouteInput{ApiId: &apiID, RouteKey: aws.String("GET /pets")})
+				return err
+			},
+			want: &svcsdktypes.ConflictException{},
+		},
+		{
+			name: "missing integration target",
+			call: func() error {
+				_, err := c.CreateRoute(ctx, &svcsdk.CreateRouteInput{
+					ApiId:    &apiID,
+					RouteKey: aws.String("POST /pets"),
+					Target:   aws.String("integrations/missing"),
+				})
+				return err
+			},
+			want: &svcsdktypes.BadRequestException{},
+		},
+		{
+			name: "missing required field",
+			call: func() error {
+				_, err := c.CreateApi(ctx, &svcsdk.CreateApiInput{ProtocolType: svcsdktypes.ProtocolTypeHttp})
+				return err
+			},
+			want: &svcsdktypes.BadRequestException{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			target := reflect.New(reflect.TypeOf(tt.want))
+			if !errors.As(err, target.Interface()) {
+				t.Errorf("error = %v, want %T", err, tt.want)
+			}
+		})
+	}
+
+	injected := errors.New("injected")
+	c.Fail("GetApi", injected)
+	// The injected failure is returned once, before the API is looked up.
+	if _, err := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: &apiID}); err != injected {
+		t.Errorf("GetApi() error = %v, want the injected failure", err)
+	}
+	if _, err := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: &apiID}); err != nil {
+		t.Errorf("GetApi() error = %v after the injected failure", err)
+	}
+}
+
+func TestClient_Pagination(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	c.PageSize = 2
+	for i := 0; i < 5; i++ {
+		newHTTPAPI(t, c)
+	}
+
+	var ids []string
+	var pages int
+	var token *string
+	for {
+		out, err := c.GetApis(ctx, &svcsdk.GetApisInput{NextToken: token})
+		if err != nil {
+			t.Fatalf("GetApis() error = %v", err)
+		}
+		pages++
+		for _, item := range out.Items {
+			ids = append(ids, *item.ApiId)
+		}
+		if token = out.NextToken; token == nil {
+			break
+		}
+	}
+	if len(ids) != 5 || pages != 3 {
+		t.Errorf("GetApis() returned %d APIs in %d pages, want 5 in 3", len(ids), pages)
+	}
+}
+
+func TestClient_PartialUpdates(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	apiID := newHTTPAPI(t, c)
+	if _, err := c.CreateStage(ctx, &svcsdk.CreateStageInput{
+		ApiId:     &apiID,
+		StageName: aws.String("prod"),
+		RouteSettings: map[string]svcsdktypes.RouteSettings{
+			"GET /pets": {ThrottlingBurstLimit: aws.Int32(10)},
+		},
+	}); err != nil {
+		t.Fatalf("CreateStage() error = %v", err)
+	}
+	before, _ := c.GetStage(ctx, &svcsdk.GetStageInput{ApiId: &apiID, StageName: aws.String("prod")})
+
+	if _, err := c.UpdateStage(ctx, &svcsdk.UpdateStageInput{
+		ApiId:       &apiID,
+		StageName:   aws.String("prod"),
+		Description: aws.String("production"),
+		RouteSettings: map[string]svcsdktypes.RouteSettings{
+			"POST /pets": {ThrottlingBurstLimit: aws.Int32(5)},
+		},
+	}); err != nil {
+		t.Fatalf("UpdateStage() error = %v", err)
+	}
+	if _, err := c.DeleteRouteSettings(ctx, &svcsdk.DeleteRouteSettingsInput{
+		ApiId:     &apiID,
+		StageName: aws.String("prod"),
+		RouteKey:  aws.String("GET /pets"),
+	}); err != nil {
+		t.Fatalf("DeleteRouteSettings() error = %v", err)
+	}
+
+	after, _ := c.GetStage(ctx, &svcsdk.GetStageInput{ApiId: &apiID, StageName: aws.String("prod")})
+	if aws.ToString(after.Description) != "production" {
+		t.Errorf("Description = %v, want production", aws.ToString(after.Description))
+	}
+	want := map[string]svcsdktypes.RouteSettings{
+		"POST /pets": {
+			DetailedMetricsEnabled: aws.Bool(false),
+			LoggingLevel:           svcsdktypes.LoggingLevelOff,
+			ThrottlingBurstLimit:   aws.Int32(5),
+		},
+	}
+	if !reflect.DeepEqual(after.RouteSettings, want) {
+		t.Errorf("RouteSettings = %+v, want %+v", after.RouteSettings, want)
+	}
+	// Outputs returned earlier are not changed by later calls.
+	if _, ok := before.RouteSettings["GET /pets"]; !ok || len(before.RouteSettings) != 1 {
+		t.Errorf("earlier GetStage output changed to %+v", before.RouteSettings)
+	}
+}
+
+func TestClient_Tags(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	apiID := newHTTPAPI(t, c)
+	arn := aws.String("arn:aws:apigateway:us-west-2::/apis/" + apiID)
+
+	if _, err := c.TagResource(ctx, &svcsdk.TagResourceInput{
+		ResourceArn: arn,
+		Tags:        map[string]string{"team": "pets", "env": "dev"},
+	}); err != nil {
+		t.Fatalf("TagResource() error = %v", err)
+	}
+	if _, err := c.UntagResource(ctx, &svcsdk.UntagResourceInput{
+		ResourceArn: arn,
+		TagKeys:     []string{"env"},
+	}); err != nil {
+		t.Fatalf("UntagResource() error = %v", err)
+	}
+	out, _ := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: &apiID})
+	if want := map[string]string{"team": "pets"}; !reflect.DeepEqual(out.Tags, want) {
+		t.Errorf("Tags = %v, want %v", out.Tags, want)
+	}
+
+	_, err := c.TagResource(ctx, &svcsdk.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:apigateway:us-west-2::/apis/missing"),
+		Tags:        map[string]string{"team": "pets"},
+	})
+	var notFound *svcsdktypes.NotFoundException
+	if !errors.As(err, &notFound) {
+		t.Errorf("TagResource() error = %v, want NotFoundException", err)
+	}
+}
+
+func TestClient_ImportExport(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	body := `
+openapi: 3.0.1
+info:
+  title: pets
+  version: "1.0"
+paths:
+  /pets:
+    get: {}
+    post: {}
+`
+	imported, err := c.ImportApi(ctx, &svcsdk.ImportApiInput{Body: aws.String(body)})
+	if err != nil {
+		t.Fatalf("ImportApi() error = %v", err)
+	}
+	routeKeys := func() []string {
+		out, err := c.GetRoutes(ctx, &svcsdk.GetRoutesInput{ApiId: imported.ApiId})
+		if err != nil {
+			t.Fatalf("GetRoutes() error = %v", err)
+		}
+		var keys []string
+		for _, r := range out.Items {
+			keys = append(keys, *r.RouteKey)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	if got, want := routeKeys(), []string{"GET /pets", "POST /pets"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("routes after import = %v, want %v", got, want)
+	}
+
+	if _, err := c.ReimportApi(ctx, &svcsdk.ReimportApiInput{
+		ApiId: imported.ApiId,
+		Body:  aws.String(`{"openapi":"3.0.1","info":{"title":"pets","version":"2.0"},"paths":{"/pets":{"delete":{}}}}`),
+	}); err != nil {
+		t.Fatalf("ReimportApi() error = %v", err)
+	}
+	if got, want := routeKeys(), []string{"DELETE /pets"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("routes after reimport = %v, want %v", got, want)
+	}
+
+	out, err := c.ExportApi(ctx, &svcsdk.ExportApiInput{
+		ApiId:         imported.ApiId,
+		OutputType:    aws.String("JSON"),
+		Specification: aws.String("OAS30"),
+	})
+	if err != nil {
+		t.Fatalf("ExportApi() error = %v", err)
+	}
+	want := `{"info":{"title":"pets","version":"2.0"},"openapi":"3.0.1","paths":{"/pets":{"delete":{}}}}`
+	if string(out.Body) != want {
+		t.Errorf("ExportApi() = %s, want %s", out.Body, want)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/go-logr/logr"
+
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+)
+
+// Fixture is a resource manager served by a fake control plane, for the
+// tests of the resource packages. Its helpers fail the test on error.
+type Fixture struct {
+	T *testing.T
+	// Client is the fake control plane.
+	Client *Client
+	// Manager is the resource manager under test, and Descriptor the
+	// descriptor of its resources.
+	Manager    acktypes.AWSResourceManager
+	Descriptor acktypes.AWSResourceDescriptor
+	// Context is passed to the calls of the manager. It holds a Kubernetes
+	// client without any object.
+	Context context.Context
+}
+
+// NewFixture returns a fixture with a manager of the factory for the
+// 123456789012 account, served by an empty control plane.
+func NewFixture(t *testing.T, factory acktypes.AWSResourceManagerFactory) *Fixture {
+	t.Helper()
+	c := New()
+	rm, err := factory.ManagerFor(
+		ackcfg.Config{Partition: "aws"}, c.Config(), logr.Discard(),
+		ackmetrics.NewMetrics("apigatewayv2"), nil,
+		"123456789012", ackv1alpha1.AWSRegion(c.Region), "",
+	)
+	if err != nil {
+		t.Fatalf("ManagerFor() error = %v", err)
+	}
+	return &Fixture{
+		T:          t,
+		Client:     c,
+		Manager:    rm,
+		Descriptor: factory.ResourceDescriptor(),
+		Context:    kubefake.NewContext(context.Background()),
+	}
+}
+
+// Create creates the resource with the manager and returns its latest
+// state, as read back.
+func (f *Fixture) Create(r acktypes.AWSResource) acktypes.AWSResource {
+	f.T.Helper()
+	created, err := f.Manager.Create(f.Context, r)
+	if err != nil {
+		f.T.Fatalf("Create() error = %v", err)
+	}
+	return f.ReadOne(created)
+}
+
+// ReadOne reads the latest state of the resource with the manager.
+func (f *Fixture) ReadOne(r acktypes.AWSResource) acktypes.AWSResource {
+	f.T.Helper()
+	latest, err := f.Manager.ReadOne(f.Context, r)
+	if err != nil {
+		f.T.Fatalf("ReadOne() error = %v", err)
+	}
+	return latest
+}
+
+// Update updates the latest state of the resource to the desired one with
+// the manager.
+func (f *Fixture) Update(desired, latest acktypes.AWSResource) (acktypes.AWSResource, error) {
+	return f.Manager.Update(f.Context, desired, latest, f.Descriptor.Delta(desired, latest))
+}
+
+// Adopt returns a copy of r annotated with the adoption policy and fields,
+// populated from the fields the way the runtime does before it reads a
+// resource to adopt: the adopt policy takes the populated resource, while
+// adopt-or-create keeps the spec of r and ignores population errors.
+func (f *Fixture) Adopt(r acktypes.AWSResource, policy string, fields map[string]string) acktypes.AWSResource {
+	f.T.Helper()
+	r = r.DeepCopy()
+	annotations := map[string]string{ackv1alpha1.AnnotationAdoptionPolicy: policy}
+	if fields != nil {
+		b, err := json.Marshal(fields)
+		if err != nil {
+			f.T.Fatalf("json.Marshal() error = %v", err)
+		}
+		annotations[ackv1alpha1.AnnotationAdoptionFields] = string(b)
+	}
+	r.MetaObject().SetAnnotations(annotations)
+	if fields == nil {
+		return r
+	}
+	populated := r.DeepCopy()
+	err := populated.PopulateResourceFromAnnotation(fields)
+	if policy == "adopt-or-create" {
+		r.SetStatus(populated)
+		return r
+	}
+	if err != nil {
+		f.T.Fatalf("PopulateResourceFromAnnotation() error = %v", err)
+	}
+	return populated
+}
+
+// CreateAPI creates an API of the protocol type in the control plane and
+// returns its ID.
+func (f *Fixture) CreateAPI(protocolType svcsdktypes.ProtocolType) string {
+	f.T.Helper()
+	in := &svcsdk.CreateApiInput{Name: aws.String("pets"), ProtocolType: protocolType}
+	if protocolType == svcsdktypes.ProtocolTypeWebsocket {
+		in.RouteSelectionExpression = aws.String("$request.body.action")
+	}
+	out, err := f.Client.CreateApi(context.Background(), in)
+	if err != nil {
+		f.T.Fatalf("CreateApi() error = %v", err)
+	}
+	return *out.ApiId
+}
+
+// CreateRoute creates a route with the key in the API and returns its ID.
+func (f *Fixture) CreateRoute(apiID, routeKey string) string {
+	f.T.Helper()
+	out, err := f.Client.CreateRoute(context.Background(), &svcsdk.CreateRouteInput{
+		ApiId:    &apiID,
+		RouteKey: &routeKey,
+	})
+	if err != nil {
+		f.T.Fatalf("CreateRoute() error = %v", err)
+	}
+	return *out.RouteId
+}
+
+// CreateIntegration creates an HTTP proxy integration in the API and returns
+// its ID.
+func (f *Fixture) CreateIntegration(apiID string) string {
+	f.T.Helper()
+	out, err := f.Client.CreateIntegration(context.Background(), &svcsdk.CreateIntegrationInput{
+		ApiId:             &apiID,
+		IntegrationType:   svcsdktypes.IntegrationTypeHttpProxy,
+		IntegrationMethod: aws.String("ANY"),
+		IntegrationUri:    aws.String("https://example.com"),
+	})
+	if err != nil {
+		f.T.Fatalf("CreateIntegration() error = %v", err)
+	}
+	return *out.IntegrationId
+}
+
+// CreateStage creates a stage with the name in the API.
+func (f *Fixture) CreateStage(apiID, stageName string) {
+	f.T.Helper()
+	if _, err := f.Client.CreateStage(context.Background(), &svcsdk.CreateStageInput{
+		ApiId:     &apiID,
+		StageName: &stageName,
+	}); err != nil {
+		f.T.Fatalf("CreateStage() error = %v", err)
+	}
+}
+
+// CreateDomainName creates a custom domain name.
+func (f *Fixture) CreateDomainName(domainName string) {
+	f.T.Helper()
+	if _, err := f.Client.CreateDomainName(context.Background(), &svcsdk.CreateDomainNameInput{
+		DomainName: &domainName,
+	}); err != nil {
+		f.T.Fatalf("CreateDomainName() error = %v", err)
+	}
+}
+
+// Suite is the table of the create, read, update and delete tests shared by
+// the resource managers. R is the resource type of the manager.
+type Suite[R acktypes.AWSResource] struct {
+	// Factory returns the factory of the resource manager under test.
+	Factory func() acktypes.AWSResourceManagerFactory
+	// New creates the resources the resource under test depends on in the
+	// fixture, and returns the desired state of a resource to create.
+	New func(f *Fixture) R
+	// Missing points a created resource at one that does not exist.
+	Missing func(r R)
+	// Create, Update and Delete are the cases of the tests of the
+	// operations. Every update and delete case starts from the resource of
+	// New once created. The delete test also checks that a missing resource
+	// cannot be deleted.
+	Create []Case[R]
+	Update []Case[R]
+	Delete []Case[R]
+}
+
+// Case is a case of a Suite.
+type Case[R acktypes.AWSResource] struct {
+	Name string
+	// Setup prepares the fixture before the operation, e.g. by creating
+	// another resource with the same key. It is called with the resource to
+	// create for creations, and with the created resource for updates and
+	// deletes, which is read back after it.
+	Setup func(f *Fixture, r R)
+	// Change changes the resource passed to the operation.
+	Change func(r R)
+	// WantErr is the error the operation is expected to fail with, either a
+	// sentinel like ackerr.Terminal, an error with the same message created
+	// with errors.New, or an error of the expected type, e.g.
+	// &svcsdktypes.ConflictException{}. Nil if the operation succeeds.
+	WantErr error
+	// WantCalls are the operations the operation is expected to call, if
+	// set. An empty slice expects no call.
+	WantCalls []string
+	// Check checks the outcome of the operation, given the latest state of
+	// the resource read back after it. Not called for failed creations and
+	// deleted resources.
+	Check func(t *testing.T, f *Fixture, r R)
+}
+
+// Run runs the tests of the suite, each case on a new fixture.
+func (s Suite[R]) Run(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		for _, tc := range s.Create {
+			t.Run(tc.Name, func(t *testing.T) {
+				f := NewFixture(t, s.Factory())
+				r := s.New(f)
+				s.change(f, tc, r)
+				f.Client.ResetCalls()
+				created, err := f.Manager.Create(f.Context, r)
+				checkCalls(t, f, tc.WantCalls)
+				if !checkErr(t, "Create", err, tc.WantErr) || tc.WantErr != nil {
+					return
+				}
+				if tc.Check != nil {
+					tc.Check(t, f, f.ReadOne(created).(R))
+				}
+			})
+		}
+	})
+	t.Run("ReadOne", func(t *testing.T) {
+		f := NewFixture(t, s.Factory())
+		r := s.New(f)
+		if _, err := f.Manager.ReadOne(f.Context, r.DeepCopy()); err != ackerr.NotFound {
+			t.Errorf("ReadOne() of a resource not created yet error = %v, want %v", err, ackerr.NotFound)
+		}
+		existing := f.Create(r).(R)
+		if _, err := f.Manager.ReadOne(f.Context, existing); err != nil {
+			t.Errorf("ReadOne() error = %v", err)
+		}
+		missing := existing.DeepCopy().(R)
+		s.Missing(missing)
+		if _, err := f.Manager.ReadOne(f.Context, missing); err != ackerr.NotFound {
+			t.Errorf("ReadOne() of a missing resource error = %v, want %v", err, ackerr.NotFound)
+		}
+	})
+	t.Run("Update", func(t *testing.T) {
+		for _, tc := range s.Update {
+			t.Run(tc.Name, func(t *testing.T) {
+				f := NewFixture(t, s.Factory())
+				latest := s.setup(f, tc)
+				desired := latest.DeepCopy().(R)
+				if tc.Change != nil {
+					tc.Change(desired)
+				}
+				f.Client.ResetCalls()
+				_, err := f.Update(desired, latest)
+				checkCalls(t, f, tc.WantCalls)
+				if checkErr(t, "Update", err, tc.WantErr) && tc.Check != nil {
+					tc.Check(t, f, f.ReadOne(latest).(R))
+				}
+			})
+		}
+	})
+	deletes := append([]Case[R]{{
+		Name:    "already gone",
+		Change:  s.Missing,
+		WantErr: &svcsdktypes.NotFoundException{},
+	}}, s.Delete...)
+	t.Run("Delete", func(t *testing.T) {
+		for _, tc := range deletes {
+			t.Run(tc.Name, func(t *testing.T) {
+				f := NewFixture(t, s.Factory())
+				latest := s.setup(f, tc)
+				r := latest.DeepCopy().(R)
+				if tc.Change != nil {
+					tc.Change(r)
+				}
+				f.Client.ResetCalls()
+				_, err := f.Manager.Delete(f.Context, r)
+				checkCalls(t, f, tc.WantCalls)
+				if !checkErr(t, "Delete", err, tc.WantErr) {
+					return
+				}
+				_, err = f.Manager.ReadOne(f.Context, latest)
+				if gone := err == ackerr.NotFound; gone != (tc.WantErr == nil) {
+					t.Fatalf("deleted = %v, want %v", gone, tc.WantErr == nil)
+				}
+				if tc.Check != nil && tc.WantErr != nil {
+					tc.Check(t, f, f.ReadOne(latest).(R))
+				}
+			})
+		}
+	})
+}
+
+// setup creates the resource of New, sets the case up with it and returns
+// its latest state.
+func (s Suite[R]) setup(f *Fixture, tc Case[R]) R {
+	latest := f.Create(s.New(f)).(R)
+	if tc.Setup == nil {
+		return latest
+	}
+	tc.Setup(f, latest)
+	return f.ReadOne(latest).(R)
+}
+
+func (s Suite[R]) change(f *Fixture, tc Case[R], r R) {
+	if tc.Setup != nil {
+		tc.Setup(f, r)
+	}
+	if tc.Change != nil {
+		tc.Change(r)
+	}
+}
+
+// checkCalls checks the operations called since the calls were reset, if
+// want is set.
+func checkCalls(t *testing.T, f *Fixture, want []string) {
+	t.Helper()
+	if want == nil {
+		return
+	}
+	if got := f.Client.Calls(); len(got)+len(want) > 0 && !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+// sentinelType is the type of the sentinel errors, compared by value.
+var sentinelType = reflect.TypeOf(errors.New(""))
+
+// checkErr reports whether err is want, or has the message of want if want is
+// a sentinel error, or is of the type of want otherwise.
+func checkErr(t *testing.T, op string, err, want error) bool {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Errorf("%s() error = %v", op, err)
+			return false
+		}
+		return true
+	}
+	switch {
+	case errors.Is(err, want):
+	case reflect.TypeOf(want) == sentinelType:
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("%s() error = %v, want %v", op, err, want)
+			return false
+		}
+	case !errors.As(err, reflect.New(reflect.TypeOf(want)).Interface()):
+		t.Errorf("%s() error = %v, want %T", op, err, want)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package sdkapi defines the subset of the API Gateway v2 client used by the
+// resource managers, so that they can be exercised against the in-memory
+// implementation of the fake package instead of AWS.
+package sdkapi
+
+import (
+	"context"
+
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+)
+
+// Client is implemented by *apigatewayv2.Client.
+type Client interface {
+	CreateApi(context.Context, *svcsdk.CreateApiInput, ...func(*svcsdk.Options)) (*svcsdk.CreateApiOutput, error)
+	CreateApiMapping(context.Context, *svcsdk.CreateApiMappingInput, ...func(*svcsdk.Options)) (*svcsdk.CreateApiMappingOutput, error)
+	CreateAuthorizer(context.Context, *svcsdk.CreateAuthorizerInput, ...func(*svcsdk.Options)) (*svcsdk.CreateAuthorizerOutput, error)
+	CreateDeployment(context.Context, *svcsdk.CreateDeploymentInput, ...func(*svcsdk.Options)) (*svcsdk.CreateDeploymentOutput, error)
+	CreateDomainName(context.Context, *svcsdk.CreateDomainNameInput, ...func(*svcsdk.Options)) (*svcsdk.CreateDomainNameOutput, error)
+	CreateIntegration(context.Context, *svcsdk.CreateIntegrationInput, ...func(*svcsdk.Options)) (*svcsdk.CreateIntegrationOutput, error)
+	CreateIntegrationResponse(context.Context, *svcsdk.CreateIntegrationResponseInput, ...func(*svcsdk.Options)) (*svcsdk.CreateIntegrationResponseOutput, error)
+	CreateModel(context.Context, *svcsdk.CreateModelInput, ...func(*svcsdk.Options)) (*svcsdk.CreateModelOutput, error)
+	CreateRoute(context.Context, *svcsdk.CreateRouteInput, ...func(*svcsdk.Options)) (*svcsdk.CreateRouteOutput, error)
+	CreateRouteResponse(context.Context, *svcsdk.CreateRouteResponseInput, ...func(*svcsdk.Options)) (*svcsdk.CreateRouteResponseOutput, error)
+	CreateStage(context.Context, *svcsdk.CreateStageInput, ...func(*svcsdk.Options)) (*svcsdk.CreateStageOutput, error)
+	CreateVpcLink(context.Context, *svcsdk.CreateVpcLinkInput, ...func(*svcsdk.Options)) (*svcsdk.CreateVpcLinkOutput, error)
+	DeleteApi(context.Context, *svcsdk.DeleteApiInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteApiOutput, error)
+	DeleteApiMapping(context.Context, *svcsdk.DeleteApiMappingInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteApiMappingOutput, error)
+	DeleteAuthorizer(context.Context, *svcsdk.DeleteAuthorizerInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteAuthorizerOutput, error)
+	DeleteCorsConfiguration(context.Context, *svcsdk.DeleteCorsConfigurationInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteCorsConfigurationOutput, error)
+	DeleteDeployment(context.Context, *svcsdk.DeleteDeploymentInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteDeploymentOutput, error)
+	DeleteDomainName(context.Context, *svcsdk.DeleteDomainNameInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteDomainNameOutput, error)
+	DeleteIntegration(context.Context, *svcsdk.DeleteIntegrationInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteIntegrationOutput, error)
+	DeleteIntegrationResponse(context.Context, *svcsdk.DeleteIntegrationResponseInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteIntegrationResponseOutput, error)
+	DeleteModel(context.Context, *svcsdk.DeleteModelInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteModelOutput, error)
+	DeleteRoute(context.Context, *svcsdk.DeleteRouteInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteOutput, error)
+	DeleteRouteRequestParameter(context.Context, *svcsdk.DeleteRouteRequestParameterInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteRequestParameterOutput, error)
+	DeleteRouteResponse(context.Context, *svcsdk.DeleteRouteResponseInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteResponseOutput, error)
+	DeleteRouteSettings(context.Context, *svcsdk.DeleteRouteSettingsInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteRouteSettingsOutput, error)
+	DeleteStage(context.Context, *svcsdk.DeleteStageInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteStageOutput, error)
+	DeleteVpcLink(context.Context, *svcsdk.DeleteVpcLinkInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteVpcLinkOutput, error)
+	ExportApi(context.Context, *svcsdk.ExportApiInput, ...func(*svcsdk.Options)) (*svcsdk.ExportApiOutput, error)
+	GetApi(context.Context, *svcsdk.GetApiInput, ...func(*svcsdk.Options)) (*svcsdk.GetApiOutput, error)
+	GetApiMapping(context.Context, *svcsdk.GetApiMappingInput, ...func(*svcsdk.Options)) (*svcsdk.GetApiMappingOutput, error)
+	GetApis(context.Context, *svcsdk.GetApisInput, ...func(*svcsdk.Options)) (*svcsdk.GetApisOutput, error)
+	GetAuthorizer(context.Context, *svcsdk.GetAuthorizerInput, ...func(*svcsdk.Options)) (*svcsdk.GetAuthorizerOutput, error)
+	GetDeployment(context.Context, *svcsdk.GetDeploymentInput, ...func(*svcsdk.Options)) (*svcsdk.GetDeploymentOutput, error)
+	GetDomainName(context.Context, *svcsdk.GetDomainNameInput, ...func(*svcsdk.Options)) (*svcsdk.GetDomainNameOutput, error)
+	GetIntegration(context.Context, *svcsdk.GetIntegrationInput, ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationOutput, error)
+	GetIntegrationResponse(context.Context, *svcsdk.GetIntegrationResponseInput, ...func(*svcsdk.Options)) (*svcsdk.GetIntegrationResponseOutput, error)
+	GetModel(context.Context, *svcsdk.GetModelInput, ...func(*svcsdk.Options)) (*svcsdk.GetModelOutput, error)
+	GetRoute(context.Context, *svcsdk.GetRouteInput, ...func(*svcsdk.Options)) (*svcsdk.GetRouteOutput, error)
+	GetRouteResponse(context.Context, *svcsdk.GetRouteResponseInput, ...func(*svcsdk.Options)) (*svcsdk.GetRouteResponseOutput, error)
+	GetRoutes(context.Context, *svcsdk.GetRoutesInput, ...func(*svcsdk.Options)) (*svcsdk.GetRoutesOutput, error)
+	GetStage(context.Context, *svcsdk.GetStageInput, ...func(*svcsdk.Options)) (*svcsdk.GetStageOutput, error)
+	GetVpcLink(context.Context, *svcsdk.GetVpcLinkInput, ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinkOutput, error)
+	GetVpcLinks(context.Context, *svcsdk.GetVpcLinksInput, ...func(*svcsdk.Options)) (*svcsdk.GetVpcLinksOutput, error)
+	ImportApi(context.Context, *svcsdk.ImportApiInput, ...func(*svcsdk.Options)) (*svcsdk.ImportApiOutput, error)
+	ReimportApi(context.Context, *svcsdk.ReimportApiInput, ...func(*svcsdk.Options)) (*svcsdk.ReimportApiOutput, error)
+	TagResource(context.Context, *svcsdk.TagResourceInput, ...func(*svcsdk.Options)) (*svcsdk.TagResourceOutput, error)
+	UntagResource(context.Context, *svcsdk.UntagResourceInput, ...func(*svcsdk.Options)) (*svcsdk.UntagResourceOutput, error)
+	UpdateApi(context.Context, *svcsdk.UpdateApiInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateApiOutput, error)
+	UpdateApiMapping(context.Context, *svcsdk.UpdateApiMappingInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateApiMappingOutput, error)
+	UpdateAuthorizer(context.Context, *svcsdk.UpdateAuthorizerInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateAuthorizerOutput, error)
+	UpdateDeployment(context.Context, *svcsdk.UpdateDeploymentInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateDeploymentOutput, error)
+	UpdateDomainName(context.Context, *svcsdk.UpdateDomainNameInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateDomainNameOutput, error)
+	UpdateIntegration(context.Context, *svcsdk.UpdateIntegrationInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateIntegrationOutput, error)
+	UpdateIntegrationResponse(context.Context, *svcsdk.UpdateIntegrationResponseInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateIntegrationResponseOutput, error)
+	UpdateModel(context.Context, *svcsdk.UpdateModelInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateModelOutput, error)
+	UpdateRoute(context.Context, *svcsdk.UpdateRouteInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateRouteOutput, error)
+	UpdateRouteResponse(context.Context, *svcsdk.UpdateRouteResponseInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateRouteResponseOutput, error)
+	UpdateStage(context.Context, *svcsdk.UpdateStageInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateStageOutput, error)
+	UpdateVpcLink(context.Context, *svcsdk.UpdateVpcLinkInput, ...func(*svcsdk.Options)) (*svcsdk.UpdateVpcLinkOutput, error)
+}
+
+var _ Client = (*svcsdk.Client)(nil)
@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+)
+
+type metricsRecorder interface {
+	RecordAPICall(opType string, opID string, err error)
+}
+
+type tagsClient interface {
+	TagResource(context.Context, *svcsdk.TagResourceInput, ...func(*svcsdk.Options)) (*svcsdk.TagResourceOutput, error)
+	UntagResource(context.Context, *svcsdk.UntagResourceInput, ...func(*svcsdk.Options)) (*svcsdk.UntagResourceOutput, error)
+}
+
+// SyncTags calls the TagResource and UntagResource APIs so that the tags on
+// the API Gateway resource identified by resourceARN match desiredTags.
+// resourceARN is taken from the resource metadata and must be set.
+// Tags that are only present in latestTags are removed and tags that are new
+// or have a changed value in desiredTags are added. AWS-managed tags and the
+// systemTags set by the controller via --resource-tags are left untouched.
+func SyncTags(
+	ctx context.Context,
+	client tagsClient,
+	mr metricsRecorder,
+	systemTags []string,
+	resourceARN *ackv1alpha1.AWSResourceName,
+	desiredTags acktags.Tags,
+	latestTags acktags.Tags,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("tags.SyncTags")
+	defer func() {
+		exit(err)
+	}()
+
+	if resourceARN == nil {
+		return errors.New("cannot sync tags: resource ARN is not known")
+	}
+	arn := string(*resourceARN)
+
+	added, _, removed := ackcompare.GetTagsDifference(
+		withoutSystemTags(latestTags, systemTags),
+		withoutSystemTags(desiredTags, systemTags),
+	)
+
+	if len(removed) > 0 {
+		toRemove := make([]string, 0, len(removed))
+		for key := range removed {
+			toRemove = append(toRemove, key)
+		}
+		rlog.Debug("removing tags from resource", "tags", toRemove)
+		_, err = client.UntagResource(
+			ctx,
+			&svcsdk.UntagResourceInput{
+				ResourceArn: &arn,
+				TagKeys:     toRemove,
+			},
+		)
+		mr.RecordAPICall("UPDATE", "UntagResource", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(added) > 0 {
+		rlog.Debug("adding tags to resource", "tags", added)
+		_, err = client.TagResource(
+			ctx,
+			&svcsdk.TagResourceInput{
+				ResourceArn: &arn,
+				Tags:        map[string]string(added),
+			},
+		)
+		mr.RecordAPICall("UPDATE", "TagResource", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withoutSystemTags returns a copy of tags without the AWS-managed tags,
+// whose keys start with "aws:", and the systemTags.
+func withoutSystemTags(tags acktags.Tags, systemTags []string) acktags.Tags {
+	out := acktags.Tags{}
+	for k, v := range tags {
+		if !strings.HasPrefix(k, "aws:") && !slices.Contains(systemTags, k) {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+func TestSyncTags(t *testing.T) {
+	ctx := context.Background()
+	c := fake.New()
+	latest := acktags.Tags{
+		"aws:cloudformation:stack-name": "pets",
+		"services.k8s.aws/namespace":    "default",
+		"team":                          "pets",
+		"env":                           "dev",
+	}
+	out, err := c.CreateApi(ctx, &svcsdk.CreateApiInput{
+		Name:         aws.String("pets"),
+		ProtocolType: svcsdktypes.ProtocolTypeHttp,
+		Tags:         map[string]string(latest),
+	})
+	if err != nil {
+		t.Fatalf("CreateApi() error = %v", err)
+	}
+	arn := ackv1alpha1.AWSResourceName("arn:aws:apigateway:" + c.Region + "::/apis/" + *out.ApiId)
+	desired := acktags.Tags{"team": "orders"}
+
+	if err := SyncTags(
+		ctx, c, ackmetrics.NewMetrics("apigatewayv2"), []string{"services.k8s.aws/namespace"},
+		&arn, desired, latest,
+	); err != nil {
+		t.Fatalf("SyncTags() error = %v", err)
+	}
+	api, err := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: out.ApiId})
+	if err != nil {
+		t.Fatalf("GetApi() error = %v", err)
+	}
+	want := map[string]string{
+		"aws:cloudformation:stack-name": "pets",
+		"services.k8s.aws/namespace":    "default",
+		"team":                          "orders",
+	}
+	if !reflect.DeepEqual(api.Tags, want) {
+		t.Errorf("tags = %v, want %v", api.Tags, want)
+	}
+}
+
+func TestSyncTags_UnknownARN(t *testing.T) {
+	if err := SyncTags(
+		context.Background(), fake.New(), ackmetrics.NewMetrics("apigatewayv2"), nil,
+		nil, acktags.Tags{"team": "pets"}, acktags.Tags{},
+	); err == nil {
+		t.Errorf("SyncTags() error = nil, want an error")
+	}
+}
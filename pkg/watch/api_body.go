@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// BindAPIBodySources makes the API controller reconcile an API whenever the
+// ConfigMap or Secret referenced in its Spec.BodyFrom changes, so that edits
+// to the OpenAPI definition are reimported without touching the API. It is a
+// no-op when the API reconciler is not enabled.
+func BindAPIBodySources(mgr *Manager, sc acktypes.ServiceController) error {
+	c, err := mgr.controllerFor(sc, "API")
+	if err != nil || c == nil {
+		return err
+	}
+	kc := mgr.GetClient()
+	if err := mgr.watch(
+		c, metadataOf("ConfigMap"), apisWithBodyFrom(kc, isConfigMapBodySource),
+	); err != nil {
+		return err
+	}
+	return mgr.watch(
+		c, metadataOf("Secret"), apisWithBodyFrom(kc, isSecretBodySource),
+	)
+}
+
+// metadataOf returns an object watching only the metadata of the core objects
+// of the given kind, so that their data is not cached.
+func metadataOf(kind string) *metav1.PartialObjectMetadata {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+	return obj
+}
+
+// apisWithBodyFrom returns a map function that enqueues the APIs in the
+// namespace of the changed object whose BodyFrom refers to it.
+func apisWithBodyFrom(
+	kc client.Client,
+	refersTo func(src *svcapitypes.APIBodySource, name string) bool,
+) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		apis := &svcapitypes.APIList{}
+		if err := kc.List(ctx, apis, client.InNamespace(obj.GetNamespace())); err != nil {
+			ctrlrt.LoggerFrom(ctx).Error(err, "unable to list APIs", "namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, api := range apis.Items {
+			if api.Spec.BodyFrom == nil || !refersTo(api.Spec.BodyFrom, obj.GetName()) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: api.Namespace, Name: api.Name},
+			})
+		}
+		return requests
+	}
+}
+
+func isConfigMapBodySource(src *svcapitypes.APIBodySource, name string) bool {
+	return src.ConfigMapKeyRef != nil && src.ConfigMapKeyRef.Name == name
+}
+
+func isSecretBodySource(src *svcapitypes.APIBodySource, name string) bool {
+	return src.SecretKeyRef != nil && src.SecretKeyRef.Name == name
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/api"
+)
+
+// BindAPIExports makes the API controller reconcile an API with Spec.Export
+// set whenever one of its routes, integrations, authorizers, models or stages
+// changes, or its export ConfigMap is deleted, so that the exported
+// definition follows changes made outside of the API resource itself. It is a
+// no-op when the API reconciler is not enabled.
+func BindAPIExports(mgr *Manager, sc acktypes.ServiceController) error {
+	c, err := mgr.controllerFor(sc, "API")
+	if err != nil || c == nil {
+		return err
+	}
+	kc := mgr.GetClient()
+	if err := mgr.watch(
+		c, metadataOf("ConfigMap"), exportingOwnersOf, onlyDeletes,
+	); err != nil {
+		return err
+	}
+	for _, obj := range []client.Object{
+		&svcapitypes.Route{},
+		&svcapitypes.Integration{},
+		&svcapitypes.Authorizer{},
+		&svcapitypes.Model{},
+		&svcapitypes.Stage{},
+	} {
+		if err := mgr.watch(
+			c, obj, exportingAPIsOf(kc), exportSourceChanged(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportingOwnersOf enqueues the APIs owning a deleted export ConfigMap.
+func exportingOwnersOf(_ context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.APIVersion != svcapitypes.GroupVersion.String() || ref.Kind != "API" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: ref.Name},
+		})
+	}
+	return requests
+}
+
+// exportingAPIsOf returns a map function that enqueues the APIs with
+// Spec.Export set, in the namespace of the changed object, that the object
+// belongs to.
+func exportingAPIsOf(kc client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		src, ok := api.ExportSourceOf(obj)
+		if !ok {
+			return nil
+		}
+		apis := &svcapitypes.APIList{}
+		if err := kc.List(ctx, apis, client.InNamespace(obj.GetNamespace())); err != nil {
+			ctrlrt.LoggerFrom(ctx).Error(err, "unable to list APIs", "namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range apis.Items {
+			a := &apis.Items[i]
+			if a.Spec.Export == nil || !src.BelongsTo(a) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: a.Namespace, Name: a.Name},
+			})
+		}
+		return requests
+	}
+}
+
+// exportSourceChanged passes deletions and the updates that change the spec
+// or the identifier of a resource of an API. Status updates that leave the
+// identifier alone, like the refresh of the synced condition at the end of
+// every reconciliation, do not pass.
+func exportSourceChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+			oldSrc, _ := api.ExportSourceOf(e.ObjectOld)
+			newSrc, _ := api.ExportSourceOf(e.ObjectNew)
+			return !equalStrings(oldSrc.ID, newSrc.ID)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+func equalStrings(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
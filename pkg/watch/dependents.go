@@ -0,0 +1,67 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// onlyDeletes lets only the deletion events through.
+var onlyDeletes = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// BindDeletionDependents makes the controller of each kind that other
+// resources refer to reconcile a resource when one of the resources referring
+// to it is deleted, so that a blocked deletion resumes without waiting for its
+// requeue. Kinds whose reconciler is not enabled are skipped.
+func BindDeletionDependents(mgr *Manager, sc acktypes.ServiceController) error {
+	for _, f := range reference.Fields {
+		c, err := mgr.controllerFor(sc, f.Target)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			continue
+		}
+		if err := mgr.watch(c, f.NewObject(), referencedBy(f), onlyDeletes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referencedBy returns a map function that enqueues the resource the changed
+// object refers to with the reference field.
+func referencedBy(f reference.Field) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		target, ok := f.TargetOf(obj)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: target}}
+	}
+}
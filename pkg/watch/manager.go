@@ -0,0 +1,110 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"fmt"
+	"reflect"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Manager is the controller manager handed to the service controller. It
+// records the controllers added to it so that the watches of this package are
+// added to the controller of the resource kind they enqueue, instead of to
+// controllers of their own: each controller has its own workqueue, and two
+// workqueues feeding the same reconciler would let two workers reconcile the
+// same resource at once.
+type Manager struct {
+	ctrlrt.Manager
+	controllers []controller.Controller
+}
+
+// NewManager returns a Manager wrapping mgr.
+func NewManager(mgr ctrlrt.Manager) *Manager {
+	return &Manager{Manager: mgr}
+}
+
+// Add records the controllers before adding the runnable to the manager.
+func (m *Manager) Add(r manager.Runnable) error {
+	if c, ok := r.(controller.Controller); ok {
+		m.controllers = append(m.controllers, c)
+	}
+	return m.Manager.Add(r)
+}
+
+// controllerFor returns the controller running the reconciler of the service
+// controller for the given resource kind, or nil if that kind is not being
+// reconciled.
+func (m *Manager) controllerFor(
+	sc acktypes.ServiceController,
+	kind string,
+) (controller.Controller, error) {
+	rec := reconcilerFor(sc, kind)
+	if rec == nil {
+		return nil, nil
+	}
+	for _, c := range m.controllers {
+		if r := reconcilerOf(c); r != nil && r == any(rec) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no controller found for the %s reconciler", kind)
+}
+
+// watch makes the controller enqueue the requests returned by fn for the
+// events on objects of the type of obj that pass the predicates.
+func (m *Manager) watch(
+	c controller.Controller,
+	obj client.Object,
+	fn handler.MapFunc,
+	predicates ...predicate.Predicate,
+) error {
+	return c.Watch(source.Kind(
+		m.GetCache(), obj, handler.EnqueueRequestsFromMapFunc(fn), predicates...,
+	))
+}
+
+// reconcilerOf returns the reconciler run by a controller built by
+// controller-runtime. The controller interface does not expose it, so it is
+// read from the Do field of the controller implementation.
+func reconcilerOf(c controller.Controller) any {
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	do := v.Elem().FieldByName("Do")
+	if !do.IsValid() || !do.CanInterface() || do.IsNil() {
+		return nil
+	}
+	return do.Interface()
+}
+
+// reconcilerFor returns the reconciler of the service controller for the
+// given resource kind, or nil if that kind is not being reconciled.
+func reconcilerFor(sc acktypes.ServiceController, kind string) acktypes.AWSResourceReconciler {
+	for _, rec := range sc.GetReconcilers() {
+		if gvk := rec.GroupVersionKind(); gvk != nil && gvk.Kind == kind {
+			return rec
+		}
+	}
+	return nil
+}
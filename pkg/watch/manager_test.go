@@ -0,0 +1,46 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type testReconciler struct{}
+
+func (*testReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	return reconcile.Result{}, nil
+}
+
+func TestReconcilerOf(t *testing.T) {
+	rec := &testReconciler{}
+	c, err := controller.NewUnmanaged("route", controller.Options{
+		Reconciler:         rec,
+		SkipNameValidation: ptr.To(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reconcilerOf(c); got != any(rec) {
+		t.Errorf("reconcilerOf() = %v, want %v", got, rec)
+	}
+	if got := reconcilerOf(nil); got != nil {
+		t.Errorf("reconcilerOf(nil) = %v, want nil", got)
+	}
+}
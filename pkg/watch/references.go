@@ -0,0 +1,119 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"fmt"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// BindReferenceTargets makes the controller of each kind referring to an
+// API, Integration, Authorizer, VPCLink, Deployment, DomainName or Route
+// reconcile the referring resources as soon as the target becomes synced or
+// its identifier changes, so that a resource waiting for one of its
+// references resolves it without waiting for its requeue. Kinds whose
+// reconciler is not enabled are skipped, and so are the references to Lambda
+// functions and aliases, whose CRDs may not be installed.
+func BindReferenceTargets(mgr *Manager, sc acktypes.ServiceController) error {
+	kc := mgr.GetClient()
+
+	byKind := map[string][]reference.Field{}
+	var kinds []string
+	for _, f := range reference.Fields {
+		if f.TargetGVK().Group != svcapitypes.GroupVersion.Group {
+			continue
+		}
+		if _, ok := byKind[f.Kind]; !ok {
+			kinds = append(kinds, f.Kind)
+		}
+		byKind[f.Kind] = append(byKind[f.Kind], f)
+	}
+	for _, kind := range kinds {
+		c, err := mgr.controllerFor(sc, kind)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			continue
+		}
+		for _, f := range byKind[kind] {
+			target, err := mgr.GetScheme().New(f.TargetGVK())
+			if err != nil {
+				return err
+			}
+			targetObj, ok := target.(client.Object)
+			if !ok {
+				return fmt.Errorf("%s is not a Kubernetes object", f.TargetGVK())
+			}
+			if err := mgr.watch(c, targetObj, referrersOf(kc, f), resolvedValueChanged(f)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// referrersOf returns a map function that enqueues the resources referring
+// to the changed object with the reference field.
+func referrersOf(kr client.Reader, f reference.Field) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		target := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		referrers, err := f.Referrers(ctx, kr, target)
+		if err != nil {
+			ctrlrt.LoggerFrom(ctx).Error(
+				err, "unable to list referring resources",
+				"kind", f.Kind, "field", f.Path, "target", target,
+			)
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(referrers))
+		for _, r := range referrers {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(r)})
+		}
+		return requests
+	}
+}
+
+// resolvedValueChanged passes the updates after which the references to the
+// object with the field resolve to a new value, i.e. the object became
+// synced or its identifier changed, and the updates of its reference-grant
+// annotation, which may grant references that were denied.
+func resolvedValueChanged(f reference.Field) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			value := f.ResolvedValue(e.ObjectNew)
+			if value == "" {
+				return false
+			}
+			grant := e.ObjectNew.GetAnnotations()[reference.AnnotationReferenceGrant]
+			return value != f.ResolvedValue(e.ObjectOld) ||
+				grant != e.ObjectOld.GetAnnotations()[reference.AnnotationReferenceGrant]
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
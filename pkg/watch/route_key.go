@@ -0,0 +1,108 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package watch
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/route"
+)
+
+// BindRouteKeyConflicts registers the Route field indexes used to detect
+// Routes of the same API that share a route key, and makes the Route
+// controller reconcile the Routes left Terminal by such a conflict once the
+// Route holding the key is deleted or changes its key. It is a no-op when the
+// Route reconciler is not enabled.
+func BindRouteKeyConflicts(mgr *Manager, sc acktypes.ServiceController) error {
+	c, err := mgr.controllerFor(sc, "Route")
+	if err != nil || c == nil {
+		return err
+	}
+	indexer := mgr.GetFieldIndexer()
+	ctx := context.Background()
+	if err := indexer.IndexField(ctx, &svcapitypes.Route{}, route.APIIDField, route.APIIDOf); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &svcapitypes.Route{}, route.APIRefField, route.APIRefOf); err != nil {
+		return err
+	}
+	return mgr.watch(
+		c, &svcapitypes.Route{}, terminalRoutesSharingKey(mgr.GetClient()),
+		predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return routeKey(e.ObjectOld) != routeKey(e.ObjectNew)
+			},
+			DeleteFunc:  func(event.DeleteEvent) bool { return true },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		},
+	)
+}
+
+// terminalRoutesSharingKey returns a map function that enqueues the other
+// Routes, in any namespace, with the route key of the changed Route that are
+// Terminal, since they may have lost a route key conflict against it.
+func terminalRoutesSharingKey(kc client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		key := routeKey(obj)
+		if key == "" {
+			return nil
+		}
+		routes := &svcapitypes.RouteList{}
+		if err := kc.List(ctx, routes); err != nil {
+			ctrlrt.LoggerFrom(ctx).Error(err, "unable to list Routes")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, r := range routes.Items {
+			if r.UID == obj.GetUID() || aws.ToString(r.Spec.RouteKey) != key || !isTerminal(r.Status.Conditions) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: r.Namespace, Name: r.Name},
+			})
+		}
+		return requests
+	}
+}
+
+func routeKey(obj client.Object) string {
+	if r, ok := obj.(*svcapitypes.Route); ok {
+		return aws.ToString(r.Spec.RouteKey)
+	}
+	return ""
+}
+
+func isTerminal(conditions []*ackv1alpha1.Condition) bool {
+	for _, cond := range conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,361 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/api"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/resource/authorizer"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/routekey"
+)
+
+var (
+	authorizerTypes       = []string{"JWT", "REQUEST"}
+	connectionTypes       = []string{"INTERNET", "VPC_LINK"}
+	endpointTypes         = []string{"EDGE", "REGIONAL"}
+	integrationTypes      = []string{"AWS", "AWS_PROXY", "HTTP", "HTTP_PROXY", "MOCK"}
+	payloadFormatVersions = []string{"1.0", "2.0"}
+	securityPolicies      = []string{"TLS_1_0", "TLS_1_2"}
+	// authorizationTypes are the authorization types that need an
+	// authorizer.
+	authorizationTypes = map[string]bool{"CUSTOM": true, "JWT": true}
+)
+
+const (
+	minIntegrationTimeout = 50
+	maxIntegrationTimeout = 30000
+)
+
+var specPath = field.NewPath("spec")
+
+// httpRouteKeyShape matches the route keys that have the "METHOD /path"
+// shape of HTTP route keys.
+var httpRouteKeyShape = regexp.MustCompile(`^[A-Za-z]+ /`)
+
+func validateAPI(ko *svcapitypes.API) field.ErrorList {
+	if err := api.ValidateAPI(ko); err != nil {
+		return field.ErrorList{field.Forbidden(specPath, err.Error())}
+	}
+	return nil
+}
+
+func validateAPIMapping(ko *svcapitypes.APIMapping) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	return append(errs, referenceOrID("domainName", ko.Spec.DomainName != nil, "domainRef", ko.Spec.DomainRef != nil, true)...)
+}
+
+func validateAuthorizer(ko *svcapitypes.Authorizer) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	switch authorizerType := stringValue(ko.Spec.AuthorizerType); authorizerType {
+	case "JWT":
+		jwtPath := specPath.Child("jwtConfiguration")
+		if ko.Spec.JWTConfiguration == nil {
+			errs = append(errs, field.Required(jwtPath, "JWT authorizers require a JWT configuration"))
+		} else if stringValue(ko.Spec.JWTConfiguration.Issuer) == "" {
+			errs = append(errs, field.Required(jwtPath.Child("issuer"), "JWT authorizers require an issuer"))
+		}
+		if ko.Spec.AuthorizerURI != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("authorizerURI"), "JWT authorizers do not invoke a Lambda function"))
+		}
+		if ko.Spec.FunctionRef != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("functionRef"), "JWT authorizers do not invoke a Lambda function"))
+		}
+		if boolValue(ko.Spec.ManageInvokePermission) {
+			errs = append(errs, field.Forbidden(specPath.Child("manageInvokePermission"), "JWT authorizers do not invoke a Lambda function"))
+		}
+	case "REQUEST":
+		errs = append(errs, referenceOrID("authorizerURI", ko.Spec.AuthorizerURI != nil, "functionRef", ko.Spec.FunctionRef != nil, true)...)
+		if ko.Spec.JWTConfiguration != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("jwtConfiguration"), "only JWT authorizers take a JWT configuration"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("authorizerType"), authorizerType, authorizerTypes))
+	}
+	version := ko.Spec.AuthorizerPayloadFormatVersion
+	if version != nil && !contains(payloadFormatVersions, *version) {
+		errs = append(errs, field.NotSupported(specPath.Child("authorizerPayloadFormatVersion"), *version, payloadFormatVersions))
+	}
+	if boolValue(ko.Spec.EnableSimpleResponses) && stringValue(version) != "2.0" {
+		errs = append(errs, field.Forbidden(specPath.Child("enableSimpleResponses"), "simple responses require authorizer payload format version 2.0"))
+	}
+	return append(errs, authorizer.ValidateIdentitySource(specPath, &ko.Spec)...)
+}
+
+func validateDeployment(ko *svcapitypes.Deployment) field.ErrorList {
+	return referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+}
+
+func validateDomainName(ko *svcapitypes.DomainName) field.ErrorList {
+	var errs field.ErrorList
+	configsPath := specPath.Child("domainNameConfigurations")
+	for i, config := range ko.Spec.DomainNameConfigurations {
+		configPath := configsPath.Index(i)
+		if config == nil {
+			errs = append(errs, field.Required(configPath, "domain name configurations cannot be empty"))
+			continue
+		}
+		if stringValue(config.CertificateARN) == "" {
+			errs = append(errs, field.Required(configPath.Child("certificateARN"), "custom domain names require a certificate"))
+		}
+		if endpointType := config.EndpointType; endpointType != nil && !contains(endpointTypes, *endpointType) {
+			errs = append(errs, field.NotSupported(configPath.Child("endpointType"), *endpointType, endpointTypes))
+		}
+		if policy := config.SecurityPolicy; policy != nil && !contains(securityPolicies, *policy) {
+			errs = append(errs, field.NotSupported(configPath.Child("securityPolicy"), *policy, securityPolicies))
+		}
+	}
+	if mtls := ko.Spec.MutualTLSAuthentication; mtls != nil {
+		mtlsPath := specPath.Child("mutualTLSAuthentication")
+		if uri := stringValue(mtls.TruststoreURI); !strings.HasPrefix(uri, "s3://") {
+			errs = append(errs, field.Invalid(mtlsPath.Child("truststoreURI"), uri, "must be an S3 URL, e.g. s3://bucket/truststore.pem"))
+		}
+		for i, config := range ko.Spec.DomainNameConfigurations {
+			if config != nil && config.SecurityPolicy != nil && *config.SecurityPolicy != "TLS_1_2" {
+				errs = append(errs, field.Forbidden(configsPath.Index(i).Child("securityPolicy"), "mutual TLS authentication requires the TLS_1_2 security policy"))
+			}
+		}
+	}
+	return errs
+}
+
+func validateIntegration(ko *svcapitypes.Integration) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	errs = append(errs, referenceOrID("integrationURI", ko.Spec.IntegrationURI != nil, "functionRef", ko.Spec.FunctionRef != nil, false)...)
+	errs = append(errs, referenceOrID("integrationURI", ko.Spec.IntegrationURI != nil, "functionAliasRef", ko.Spec.FunctionAliasRef != nil, false)...)
+	errs = append(errs, referenceOrID("functionRef", ko.Spec.FunctionRef != nil, "functionAliasRef", ko.Spec.FunctionAliasRef != nil, false)...)
+	errs = append(errs, referenceOrID("connectionID", ko.Spec.ConnectionID != nil, "connectionRef", ko.Spec.ConnectionRef != nil, false)...)
+
+	integrationType := stringValue(ko.Spec.IntegrationType)
+	if !contains(integrationTypes, integrationType) {
+		errs = append(errs, field.NotSupported(specPath.Child("integrationType"), integrationType, integrationTypes))
+	}
+	hasConnection := ko.Spec.ConnectionID != nil || ko.Spec.ConnectionRef != nil
+	switch connectionType := stringValue(ko.Spec.ConnectionType); connectionType {
+	case "VPC_LINK":
+		if !hasConnection {
+			errs = append(errs, field.Required(specPath.Child("connectionID"), "VPC_LINK integrations require one of connectionID and connectionRef"))
+		}
+	case "", "INTERNET":
+		if hasConnection {
+			errs = append(errs, field.Forbidden(specPath.Child("connectionType"), "a connection can only be used with connectionType VPC_LINK"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("connectionType"), connectionType, connectionTypes))
+	}
+	if boolValue(ko.Spec.ManageInvokePermission) && integrationType != "AWS_PROXY" {
+		errs = append(errs, field.Forbidden(specPath.Child("manageInvokePermission"), "invoke permissions are managed only for AWS_PROXY integrations"))
+	}
+	if timeout := ko.Spec.TimeoutInMillis; timeout != nil && (*timeout < minIntegrationTimeout || *timeout > maxIntegrationTimeout) {
+		errs = append(errs, field.Invalid(specPath.Child("timeoutInMillis"), *timeout,
+			fmt.Sprintf("must be between %d and %d", minIntegrationTimeout, maxIntegrationTimeout)))
+	}
+	if version := ko.Spec.PayloadFormatVersion; version != nil && !contains(payloadFormatVersions, *version) {
+		errs = append(errs, field.NotSupported(specPath.Child("payloadFormatVersion"), *version, payloadFormatVersions))
+	}
+	return errs
+}
+
+func validateIntegrationResponse(ko *svcapitypes.IntegrationResponse) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	return append(errs, referenceOrID("integrationID", ko.Spec.IntegrationID != nil, "integrationRef", ko.Spec.IntegrationRef != nil, true)...)
+}
+
+func validateModel(ko *svcapitypes.Model) field.ErrorList {
+	return referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+}
+
+func validateRoute(ctx context.Context, kc client.Reader, ko *svcapitypes.Route) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	errs = append(errs, referenceOrID("authorizerID", ko.Spec.AuthorizerID != nil, "authorizerRef", ko.Spec.AuthorizerRef != nil, false)...)
+	errs = append(errs, referenceOrID("target", ko.Spec.Target != nil, "targetRef", ko.Spec.TargetRef != nil, false)...)
+	if ko.Spec.RouteKey != nil {
+		errs = append(errs, validateRouteKey(specPath.Child("routeKey"), *ko.Spec.RouteKey,
+			apiProtocol(ctx, kc, ko.Namespace, ko.Spec.APIID, ko.Spec.APIRef))...)
+	}
+	authorizationType := stringValue(ko.Spec.AuthorizationType)
+	if authorizationTypes[authorizationType] && ko.Spec.AuthorizerID == nil && ko.Spec.AuthorizerRef == nil {
+		errs = append(errs, field.Required(specPath.Child("authorizerID"),
+			fmt.Sprintf("authorization type %s requires one of authorizerID and authorizerRef", authorizationType)))
+	}
+	return errs
+}
+
+func validateRouteResponse(ko *svcapitypes.RouteResponse) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	return append(errs, referenceOrID("routeID", ko.Spec.RouteID != nil, "routeRef", ko.Spec.RouteRef != nil, true)...)
+}
+
+func validateStage(ctx context.Context, kc client.Reader, ko *svcapitypes.Stage) field.ErrorList {
+	errs := referenceOrID("apiID", ko.Spec.APIID != nil, "apiRef", ko.Spec.APIRef != nil, true)
+	errs = append(errs, referenceOrID("deploymentID", ko.Spec.DeploymentID != nil, "deploymentRef", ko.Spec.DeploymentRef != nil, false)...)
+	keys := make([]string, 0, len(ko.Spec.RouteSettings))
+	for key := range ko.Spec.RouteSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	protocolType := ""
+	if len(keys) > 0 {
+		protocolType = apiProtocol(ctx, kc, ko.Namespace, ko.Spec.APIID, ko.Spec.APIRef)
+	}
+	for _, key := range keys {
+		errs = append(errs, validateRouteKey(specPath.Child("routeSettings").Key(key), key, protocolType)...)
+	}
+	return errs
+}
+
+func validateVPCLink(ko *svcapitypes.VPCLink) field.ErrorList {
+	var errs field.ErrorList
+	if stringValue(ko.Spec.Name) == "" {
+		errs = append(errs, field.Required(specPath.Child("name"), "VPC links require a name"))
+	}
+	if len(ko.Spec.SubnetIDs) == 0 {
+		errs = append(errs, field.Required(specPath.Child("subnetIDs"), "VPC links require at least one subnet"))
+	}
+	errs = append(errs, validateIDs(specPath.Child("subnetIDs"), ko.Spec.SubnetIDs)...)
+	return append(errs, validateIDs(specPath.Child("securityGroupIDs"), ko.Spec.SecurityGroupIDs)...)
+}
+
+// validateIDs checks that a list of identifiers holds no empty or duplicate
+// identifier.
+func validateIDs(fldPath *field.Path, ids []*string) field.ErrorList {
+	var errs field.ErrorList
+	seen := map[string]bool{}
+	for i, id := range ids {
+		switch {
+		case stringValue(id) == "":
+			errs = append(errs, field.Required(fldPath.Index(i), "identifiers cannot be empty"))
+		case seen[*id]:
+			errs = append(errs, field.Duplicate(fldPath.Index(i), *id))
+		}
+		if id != nil {
+			seen[*id] = true
+		}
+	}
+	return errs
+}
+
+// referenceOrID checks that at most one of an identifier field and the
+// reference field resolved into it is set, and that one of them is set when
+// required.
+func referenceOrID(idField string, hasID bool, refField string, hasRef bool, required bool) field.ErrorList {
+	switch {
+	case hasID && hasRef:
+		return field.ErrorList{field.Forbidden(specPath.Child(refField),
+			fmt.Sprintf("only one of %s and %s can be set", idField, refField))}
+	case required && !hasID && !hasRef:
+		return field.ErrorList{field.Required(specPath.Child(idField),
+			fmt.Sprintf("one of %s and %s must be set", idField, refField))}
+	}
+	return nil
+}
+
+// validateRouteKey checks a route key against the protocol of its API. When
+// the protocol is unknown, keys with the "METHOD /path" shape must be valid
+// HTTP route keys and the other keys, such as "chat/send", valid WebSocket
+// route keys.
+func validateRouteKey(fldPath *field.Path, key string, protocolType string) field.ErrorList {
+	switch {
+	case protocolType == "HTTP":
+		return routekey.ValidateHTTP(fldPath, key)
+	case protocolType == "WEBSOCKET":
+		return routekey.ValidateWebSocket(fldPath, key)
+	case httpRouteKeyShape.MatchString(key):
+		return routekey.ValidateHTTP(fldPath, key)
+	}
+	return routekey.ValidateWebSocket(fldPath, key)
+}
+
+// apiProtocol returns the protocol type of the API that an object belongs
+// to, read from the API resource that apiRef references or, failing that,
+// from the API resource in the namespace whose status holds apiID. It
+// returns "" when the API is not known to the cluster, so that admission
+// does not depend on the order in which the resources are applied.
+func apiProtocol(
+	ctx context.Context,
+	kc client.Reader,
+	namespace string,
+	apiID *string,
+	apiRef *ackv1alpha1.AWSResourceReferenceWrapper,
+) string {
+	if kc == nil {
+		return ""
+	}
+	if apiRef != nil && apiRef.From != nil {
+		if stringValue(apiRef.From.Name) == "" {
+			return ""
+		}
+		if ns := stringValue(apiRef.From.Namespace); ns != "" {
+			namespace = ns
+		}
+		ko := &svcapitypes.API{}
+		key := types.NamespacedName{Namespace: namespace, Name: *apiRef.From.Name}
+		if err := kc.Get(ctx, key, ko); err != nil {
+			return ""
+		}
+		return protocolOf(ko)
+	}
+	if stringValue(apiID) == "" {
+		return ""
+	}
+	apis := &svcapitypes.APIList{}
+	if err := kc.List(ctx, apis, client.InNamespace(namespace)); err != nil {
+		return ""
+	}
+	for i := range apis.Items {
+		if stringValue(apis.Items[i].Status.APIID) == *apiID {
+			return protocolOf(&apis.Items[i])
+		}
+	}
+	return ""
+}
+
+// protocolOf returns the protocol type of an API. APIs imported from an
+// OpenAPI definition are HTTP APIs.
+func protocolOf(ko *svcapitypes.API) string {
+	if ko.Spec.Body != nil || ko.Spec.BodyFrom != nil {
+		return "HTTP"
+	}
+	return stringValue(ko.Spec.ProtocolType)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,393 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	kubefake "github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/kubeclient/fake"
+)
+
+func TestValidate(t *testing.T) {
+	apiRef := &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String("api")},
+	}
+	ctx := context.Background()
+	kc := kubefake.NewClient(
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       svcapitypes.APISpec{ProtocolType: aws.String("WEBSOCKET")},
+		},
+		&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Name: "pets", Namespace: "default"},
+			Spec:       svcapitypes.APISpec{ProtocolType: aws.String("HTTP")},
+			Status:     svcapitypes.APIStatus{APIID: aws.String("api-1")},
+		},
+	)
+	tests := []struct {
+		name     string
+		validate func() field.ErrorList
+		want     []string
+	}{
+		{
+			name: "API created from a body and a name",
+			validate: func() field.ErrorList {
+				return validateAPI(&svcapitypes.API{Spec: svcapitypes.APISpec{
+					Body: aws.String(`{"openapi": "3.0.1"}`),
+					Name: aws.String("pets"),
+				}})
+			},
+			want: []string{"spec: Forbidden: only 'FailOnWarnings' and 'Basepath'"},
+		},
+		{
+			name: "API without a name",
+			validate: func() field.ErrorList {
+				return validateAPI(&svcapitypes.API{Spec: svcapitypes.APISpec{ProtocolType: aws.String("HTTP")}})
+			},
+			want: []string{"'Name' and 'ProtocolType' are required"},
+		},
+		{
+			name: "API",
+			validate: func() field.ErrorList {
+				return validateAPI(&svcapitypes.API{Spec: svcapitypes.APISpec{
+					Name:         aws.String("pets"),
+					ProtocolType: aws.String("HTTP"),
+				}})
+			},
+		},
+		{
+			name: "JWT authorizer without a JWT configuration",
+			validate: func() field.ErrorList {
+				return validateAuthorizer(&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+					APIID:          aws.String("api-1"),
+					AuthorizerType: aws.String("JWT"),
+					IdentitySource: []*string{aws.String("$request.header.Authorization")},
+				}})
+			},
+			want: []string{"spec.jwtConfiguration: Required value"},
+		},
+		{
+			name: "JWT authorizer reading the token from the context",
+			validate: func() field.ErrorList {
+				return validateAuthorizer(&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+					APIID:            aws.String("api-1"),
+					AuthorizerType:   aws.String("JWT"),
+					IdentitySource:   []*string{aws.String("$context.identity.sourceIp")},
+					JWTConfiguration: &svcapitypes.JWTConfiguration{Issuer: aws.String("https://issuer.example.com")},
+				}})
+			},
+			want: []string{"spec.identitySource[0]: Invalid value"},
+		},
+		{
+			name: "REQUEST authorizer with a function reference and a URI",
+			validate: func() field.ErrorList {
+				return validateAuthorizer(&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+					APIRef:                         apiRef,
+					AuthorizerType:                 aws.String("REQUEST"),
+					AuthorizerURI:                  aws.String("arn:aws:lambda:us-west-2:123456789012:function:auth"),
+					AuthorizerPayloadFormatVersion: aws.String("2.0"),
+					FunctionRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+						From: &ackv1alpha1.AWSResourceReference{Name: aws.String("auth")},
+					},
+					IdentitySource: []*string{aws.String("$request.header.Authorization")},
+				}})
+			},
+			want: []string{"spec.functionRef: Forbidden"},
+		},
+		{
+			name: "REQUEST authorizer of an HTTP API without a payload format version",
+			validate: func() field.ErrorList {
+				return validateAuthorizer(&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+					APIRef: apiRef,
+					FunctionRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+						From: &ackv1alpha1.AWSResourceReference{Name: aws.String("auth")},
+					},
+					AuthorizerType: aws.String("REQUEST"),
+					IdentitySource: []*string{aws.String("$request.querystring.token"), aws.String("$stageVariables.tenant")},
+				}})
+			},
+			want: []string{"spec.authorizerPayloadFormatVersion: Required value"},
+		},
+		{
+			name: "REQUEST authorizer with simple responses and payload format 1.0",
+			validate: func() field.ErrorList {
+				return validateAuthorizer(&svcapitypes.Authorizer{Spec: svcapitypes.AuthorizerSpec{
+					APIRef:                         apiRef,
+					AuthorizerType:                 aws.String("REQUEST"),
+					AuthorizerURI:                  aws.String("arn:aws:lambda:us-west-2:123456789012:function:auth"),
+					AuthorizerPayloadFormatVersion: aws.String("1.0"),
+					EnableSimpleResponses:          aws.Bool(true),
+				}})
+			},
+			want: []string{"spec.enableSimpleResponses: Forbidden"},
+		},
+		{
+			name: "VPC_LINK integration without a connection",
+			validate: func() field.ErrorList {
+				return validateIntegration(&svcapitypes.Integration{Spec: svcapitypes.IntegrationSpec{
+					APIID:           aws.String("api-1"),
+					ConnectionType:  aws.String("VPC_LINK"),
+					IntegrationType: aws.String("HTTP_PROXY"),
+				}})
+			},
+			want: []string{"spec.connectionID: Required value"},
+		},
+		{
+			name: "integration with an API ID and reference",
+			validate: func() field.ErrorList {
+				return validateIntegration(&svcapitypes.Integration{Spec: svcapitypes.IntegrationSpec{
+					APIID:           aws.String("api-1"),
+					APIRef:          apiRef,
+					IntegrationType: aws.String("HTTP_PROXY"),
+					TimeoutInMillis: aws.Int64(60000),
+				}})
+			},
+			want: []string{
+				"spec.apiRef: Forbidden: only one of apiID and apiRef can be set",
+				"spec.timeoutInMillis: Invalid value: 60000",
+			},
+		},
+		{
+			name: "stage route settings",
+			validate: func() field.ErrorList {
+				return validateStage(ctx, nil, &svcapitypes.Stage{Spec: svcapitypes.StageSpec{
+					APIID: aws.String("api-1"),
+					RouteSettings: map[string]*svcapitypes.RouteSettings{
+						"GET /pets":     {},
+						"$default":      {},
+						"sendmessage":   {},
+						"chat/send":     {},
+						"GET /pets/{id": {},
+						"$unknown":      {},
+					},
+				}})
+			},
+			want: []string{`spec.routeSettings[$unknown]`, `spec.routeSettings[GET /pets/{id]`},
+		},
+		{
+			name: "stage route settings of an HTTP API",
+			validate: func() field.ErrorList {
+				return validateStage(ctx, kc, &svcapitypes.Stage{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+					Spec: svcapitypes.StageSpec{
+						APIID: aws.String("api-1"),
+						RouteSettings: map[string]*svcapitypes.RouteSettings{
+							"GET /pets": {},
+							"chat/send": {},
+						},
+					},
+				})
+			},
+			want: []string{`spec.routeSettings[chat/send]: Invalid value`},
+		},
+		{
+			name: "domain name",
+			validate: func() field.ErrorList {
+				return validateDomainName(&svcapitypes.DomainName{Spec: svcapitypes.DomainNameSpec{
+					DomainName: aws.String("api.example.com"),
+					DomainNameConfigurations: []*svcapitypes.DomainNameConfiguration{{
+						CertificateARN: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/1"),
+						EndpointType:   aws.String("REGIONAL"),
+						SecurityPolicy: aws.String("TLS_1_2"),
+					}},
+					MutualTLSAuthentication: &svcapitypes.MutualTLSAuthenticationInput{
+						TruststoreURI: aws.String("s3://bucket/truststore.pem"),
+					},
+				}})
+			},
+		},
+		{
+			name: "domain name without a certificate",
+			validate: func() field.ErrorList {
+				return validateDomainName(&svcapitypes.DomainName{Spec: svcapitypes.DomainNameSpec{
+					DomainName: aws.String("api.example.com"),
+					DomainNameConfigurations: []*svcapitypes.DomainNameConfiguration{
+						{EndpointType: aws.String("PRIVATE")},
+						nil,
+					},
+				}})
+			},
+			want: []string{
+				"spec.domainNameConfigurations[0].certificateARN: Required value",
+				`spec.domainNameConfigurations[0].endpointType: Unsupported value: "PRIVATE"`,
+				"spec.domainNameConfigurations[1]: Required value",
+			},
+		},
+		{
+			name: "domain name with mutual TLS and TLS 1.0",
+			validate: func() field.ErrorList {
+				return validateDomainName(&svcapitypes.DomainName{Spec: svcapitypes.DomainNameSpec{
+					DomainName: aws.String("api.example.com"),
+					DomainNameConfigurations: []*svcapitypes.DomainNameConfiguration{{
+						CertificateARN: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/1"),
+						SecurityPolicy: aws.String("TLS_1_0"),
+					}},
+					MutualTLSAuthentication: &svcapitypes.MutualTLSAuthenticationInput{
+						TruststoreURI: aws.String("https://bucket/truststore.pem"),
+					},
+				}})
+			},
+			want: []string{
+				"spec.mutualTLSAuthentication.truststoreURI: Invalid value",
+				"spec.domainNameConfigurations[0].securityPolicy: Forbidden",
+			},
+		},
+		{
+			name: "VPC link",
+			validate: func() field.ErrorList {
+				return validateVPCLink(&svcapitypes.VPCLink{Spec: svcapitypes.VPCLinkSpec{
+					Name:             aws.String("link"),
+					SubnetIDs:        []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+					SecurityGroupIDs: []*string{aws.String("sg-1")},
+				}})
+			},
+		},
+		{
+			name: "VPC link without a name or subnets",
+			validate: func() field.ErrorList {
+				return validateVPCLink(&svcapitypes.VPCLink{Spec: svcapitypes.VPCLinkSpec{
+					SecurityGroupIDs: []*string{aws.String("")},
+				}})
+			},
+			want: []string{
+				"spec.name: Required value",
+				"spec.subnetIDs: Required value",
+				"spec.securityGroupIDs[0]: Required value",
+			},
+		},
+		{
+			name: "VPC link with a duplicate subnet",
+			validate: func() field.ErrorList {
+				return validateVPCLink(&svcapitypes.VPCLink{Spec: svcapitypes.VPCLinkSpec{
+					Name:      aws.String("link"),
+					SubnetIDs: []*string{aws.String("subnet-1"), aws.String("subnet-1")},
+				}})
+			},
+			want: []string{`spec.subnetIDs[1]: Duplicate value: "subnet-1"`},
+		},
+		{
+			name: "route with a custom authorizer but no authorizer",
+			validate: func() field.ErrorList {
+				return validateRoute(ctx, nil, &svcapitypes.Route{Spec: svcapitypes.RouteSpec{
+					APIID:             aws.String("api-1"),
+					AuthorizationType: aws.String("CUSTOM"),
+					RouteKey:          aws.String("GET /pets"),
+				}})
+			},
+			want: []string{"spec.authorizerID: Required value"},
+		},
+		{
+			name: "WebSocket route key of an unknown API",
+			validate: func() field.ErrorList {
+				return validateRoute(ctx, kc, &svcapitypes.Route{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+					Spec: svcapitypes.RouteSpec{
+						APIID:    aws.String("api-2"),
+						RouteKey: aws.String("chat/send"),
+					},
+				})
+			},
+		},
+		{
+			name: "HTTP route key of an unknown API",
+			validate: func() field.ErrorList {
+				return validateRoute(ctx, nil, &svcapitypes.Route{Spec: svcapitypes.RouteSpec{
+					APIID:    aws.String("api-2"),
+					RouteKey: aws.String("FETCH /pets"),
+				}})
+			},
+			want: []string{`spec.routeKey: Unsupported value: "FETCH"`},
+		},
+		{
+			name: "route key of a referenced WebSocket API",
+			validate: func() field.ErrorList {
+				return validateRoute(ctx, kc, &svcapitypes.Route{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+					Spec: svcapitypes.RouteSpec{
+						APIRef:   apiRef,
+						RouteKey: aws.String("GET /pets/{id"),
+					},
+				})
+			},
+		},
+		{
+			name: "route key of an HTTP API",
+			validate: func() field.ErrorList {
+				return validateRoute(ctx, kc, &svcapitypes.Route{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+					Spec: svcapitypes.RouteSpec{
+						APIID:    aws.String("api-1"),
+						RouteKey: aws.String("sendmessage"),
+					},
+				})
+			},
+			want: []string{`spec.routeKey: Invalid value: "sendmessage"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.validate()
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got errors %v, want %d", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i].Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	v := &validator[*svcapitypes.Stage]{
+		kind:     "Stage",
+		validate: validateStage,
+		reader: kubefake.NewClient(&svcapitypes.API{
+			ObjectMeta: metav1.ObjectMeta{Name: "pets", Namespace: "default"},
+			Spec:       svcapitypes.APISpec{ProtocolType: aws.String("HTTP")},
+			Status:     svcapitypes.APIStatus{APIID: aws.String("api-1")},
+		}),
+	}
+	invalid := &svcapitypes.Stage{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: svcapitypes.StageSpec{
+			APIID:         aws.String("api-1"),
+			RouteSettings: map[string]*svcapitypes.RouteSettings{"GET/pets": {}},
+		},
+	}
+	if _, err := v.ValidateCreate(context.Background(), invalid); err == nil {
+		t.Fatal("ValidateCreate() error = nil, want the invalid spec rejected")
+	}
+
+	// errors the object already had do not block updates
+	updated := invalid.DeepCopy()
+	updated.Finalizers = []string{"finalizers.apigatewayv2.services.k8s.aws/Stage"}
+	if _, err := v.ValidateUpdate(context.Background(), invalid, updated); err != nil {
+		t.Errorf("ValidateUpdate() error = %v, want nil", err)
+	}
+
+	updated.Spec.RouteSettings["$unknown"] = &svcapitypes.RouteSettings{}
+	if _, err := v.ValidateUpdate(context.Background(), invalid, updated); err == nil {
+		t.Error("ValidateUpdate() error = nil, want the new error reported")
+	}
+}
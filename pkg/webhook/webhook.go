@@ -0,0 +1,143 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package webhook registers validating admission webhooks for the
+// apigatewayv2 resources. They reject specs that can never be reconciled when
+// they are applied, instead of after a round-trip through the controller.
+// The webhooks are served when the controller runs with
+// --enable-webhook-server; config/overlays/webhook installs them.
+package webhook
+
+import (
+	"context"
+
+	ackrtwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+// WebhookTypeValidating identifies the validating webhooks in the ACK
+// webhook registry.
+const WebhookTypeValidating = "validating"
+
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-api,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=apis,verbs=create;update,versions=v1alpha1,name=vapi.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-apimapping,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=apimappings,verbs=create;update,versions=v1alpha1,name=vapimapping.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-authorizer,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=authorizers,verbs=create;update,versions=v1alpha1,name=vauthorizer.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-deployment,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=deployments,verbs=create;update,versions=v1alpha1,name=vdeployment.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-domainname,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=domainnames,verbs=create;update,versions=v1alpha1,name=vdomainname.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-integration,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=integrations,verbs=create;update,versions=v1alpha1,name=vintegration.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-integrationresponse,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=integrationresponses,verbs=create;update,versions=v1alpha1,name=vintegrationresponse.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-model,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=models,verbs=create;update,versions=v1alpha1,name=vmodel.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-route,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=routes,verbs=create;update,versions=v1alpha1,name=vroute.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-routeresponse,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=routeresponses,verbs=create;update,versions=v1alpha1,name=vrouteresponse.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-stage,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=stages,verbs=create;update,versions=v1alpha1,name=vstage.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apigatewayv2-services-k8s-aws-v1alpha1-vpclink,mutating=false,failurePolicy=fail,sideEffects=None,groups=apigatewayv2.services.k8s.aws,resources=vpclinks,verbs=create;update,versions=v1alpha1,name=vvpclink.apigatewayv2.services.k8s.aws,admissionReviewVersions=v1
+
+func init() {
+	register("API", &svcapitypes.API{}, validateAPI)
+	register("APIMapping", &svcapitypes.APIMapping{}, validateAPIMapping)
+	register("Authorizer", &svcapitypes.Authorizer{}, validateAuthorizer)
+	register("Deployment", &svcapitypes.Deployment{}, validateDeployment)
+	register("DomainName", &svcapitypes.DomainName{}, validateDomainName)
+	register("Integration", &svcapitypes.Integration{}, validateIntegration)
+	register("IntegrationResponse", &svcapitypes.IntegrationResponse{}, validateIntegrationResponse)
+	register("Model", &svcapitypes.Model{}, validateModel)
+	registerWithReader("Route", &svcapitypes.Route{}, validateRoute)
+	register("RouteResponse", &svcapitypes.RouteResponse{}, validateRouteResponse)
+	registerWithReader("Stage", &svcapitypes.Stage{}, validateStage)
+	register("VPCLink", &svcapitypes.VPCLink{}, validateVPCLink)
+}
+
+// register adds the validating webhook of a kind to the ACK webhook
+// registry, from which the controller sets up the webhooks it serves.
+func register[T client.Object](kind string, obj T, validate func(T) field.ErrorList) {
+	registerWithReader(kind, obj, func(_ context.Context, _ client.Reader, ko T) field.ErrorList {
+		return validate(ko)
+	})
+}
+
+// registerWithReader registers the validating webhook of a kind whose
+// validation reads other objects, such as the API an object belongs to,
+// through the client of the manager.
+func registerWithReader[T client.Object](
+	kind string,
+	obj T,
+	validate func(context.Context, client.Reader, T) field.ErrorList,
+) {
+	v := &validator[T]{kind: kind, validate: validate}
+	err := ackrtwebhook.RegisterWebhook(ackrtwebhook.New(
+		svcapitypes.GroupVersion.Version,
+		kind,
+		WebhookTypeValidating,
+		func(mgr ctrlrt.Manager) error {
+			v.reader = mgr.GetClient()
+			return ctrlrt.NewWebhookManagedBy(mgr, obj).WithValidator(v).Complete()
+		},
+	))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// validator adapts a spec validation function to admission.Validator.
+type validator[T client.Object] struct {
+	kind     string
+	validate func(context.Context, client.Reader, T) field.ErrorList
+	// reader reads the objects that the validation depends on. It is nil
+	// until the webhook is set up, and in tests.
+	reader client.Reader
+}
+
+// ValidateCreate rejects the object if its spec is invalid.
+func (v *validator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	return nil, v.invalid(obj, v.validate(ctx, v.reader, obj))
+}
+
+// ValidateUpdate rejects the object if the update makes its spec invalid.
+// Errors that the object already had are let through, so that the controller
+// can keep updating the metadata of objects created before the webhook, and
+// objects being deleted are never rejected.
+func (v *validator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	if newObj.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	existing := map[string]bool{}
+	for _, err := range v.validate(ctx, v.reader, oldObj) {
+		existing[err.Error()] = true
+	}
+	var errs field.ErrorList
+	for _, err := range v.validate(ctx, v.reader, newObj) {
+		if !existing[err.Error()] {
+			errs = append(errs, err)
+		}
+	}
+	return nil, v.invalid(newObj, errs)
+}
+
+// ValidateDelete never rejects a deletion.
+func (v *validator[T]) ValidateDelete(ctx context.Context, obj T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *validator[T]) invalid(obj T, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	gk := svcapitypes.GroupVersion.WithKind(v.kind).GroupKind()
+	return apierrors.NewInvalid(gk, obj.GetName(), errs)
+}
@@ -0,0 +1,5 @@
+    // read the OpenAPI definition from the ConfigMap or Secret in BodyFrom
+    // into a copy of the resource
+    if ko, hasBodyFrom, err := resolveReferenceForBody(ctx, apiReader, rm.concreteResource(res).ko); hasBodyFrom || err != nil {
+        return &resource{ko}, hasBodyFrom, err
+    }
\ No newline at end of file
@@ -0,0 +1,3 @@
+    rm.setResourceARN(ko)
+    rm.exportAPI(ctx, ko)
+    clearResolvedBody(ko)
\ No newline at end of file
@@ -0,0 +1,3 @@
+    rm.setResourceARN(ko)
+    rm.checkExport(ctx, ko)
+    clearResolvedBody(ko)
\ No newline at end of file
@@ -0,0 +1,6 @@
+    // resolve the Lambda Function reference into the AuthorizerURI
+    if fieldHasReferences, err := rm.resolveReferenceForAuthorizerURI(ctx, apiReader, ko); err != nil {
+        return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+    } else {
+        resourceHasReferences = resourceHasReferences || fieldHasReferences
+    }
@@ -0,0 +1,3 @@
+    if err := validateIdentitySource(desired.ko); err != nil {
+        return nil, err
+    }
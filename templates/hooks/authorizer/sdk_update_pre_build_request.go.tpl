@@ -0,0 +1,13 @@
+    if delta.DifferentAt("Spec.IdentitySource") ||
+        delta.DifferentAt("Spec.AuthorizerType") ||
+        delta.DifferentAt("Spec.AuthorizerPayloadFormatVersion") ||
+        delta.DifferentAt("Spec.AuthorizerResultTTLInSeconds") {
+        if err := validateIdentitySource(desired.ko); err != nil {
+            return nil, err
+        }
+    }
+    if delta.DifferentAt("Spec.ManageInvokePermission") {
+        if err := rm.syncInvokePermission(ctx, desired.ko); err != nil {
+            return nil, err
+        }
+    }
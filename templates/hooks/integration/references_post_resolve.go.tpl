@@ -0,0 +1,7 @@
+    // resolve the Lambda Function or Alias references into the
+    // IntegrationURI
+    if fieldHasReferences, err := rm.resolveReferenceForIntegrationURI(ctx, apiReader, ko); err != nil {
+        return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+    } else {
+        resourceHasReferences = resourceHasReferences || fieldHasReferences
+    }
\ No newline at end of file
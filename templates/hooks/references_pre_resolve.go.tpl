@@ -0,0 +1,3 @@
+    if err := rm.setReferencesResolved(ctx, apiReader, ko); err != nil {
+        return &resource{ko}, true, err
+    }
\ No newline at end of file
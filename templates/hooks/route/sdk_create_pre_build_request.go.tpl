@@ -0,0 +1,3 @@
+    if err := rm.validateRouteKey(ctx, desired.ko); err != nil {
+        return nil, err
+    }
@@ -0,0 +1,10 @@
+    if delta.DifferentAt("Spec.RouteKey") {
+        if err := rm.validateRouteKey(ctx, desired.ko); err != nil {
+            return nil, err
+        }
+    }
+    if delta.DifferentAt("Spec.RequestParameters") {
+        if err := rm.deleteRemovedRequestParameters(ctx, desired, latest); err != nil {
+            return nil, err
+        }
+    }
\ No newline at end of file
@@ -0,0 +1,8 @@
+    // resolve the Model references in ResponseModelRefs into the model names
+    // expected by ResponseModels, in a copy of the resource
+    if resolved, fieldHasReferences, err := rm.resolveReferenceForResponseModels(ctx, apiReader, ko); err != nil {
+        return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+    } else {
+        ko = resolved
+        resourceHasReferences = resourceHasReferences || fieldHasReferences
+    }
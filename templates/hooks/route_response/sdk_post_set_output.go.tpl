@@ -0,0 +1,2 @@
+    rm.setResourceARN(ko)
+    clearResolvedResponseModels(ko)
\ No newline at end of file
@@ -0,0 +1,4 @@
+    rm.setResourceARN(ko)
+    if err := rm.syncInvokePermission(ctx, ko); err != nil {
+        return &resource{ko}, err
+    }
\ No newline at end of file
@@ -0,0 +1,6 @@
+    if err := rm.checkDependents(ctx, r); err != nil {
+        return nil, err
+    }
+    if err := rm.removeInvokePermission(ctx, r.ko); err != nil {
+        return nil, err
+    }
\ No newline at end of file
@@ -0,0 +1,3 @@
+    if r, err = rm.resolveNaturalKey(ctx, r); err != nil {
+        return nil, err
+    }
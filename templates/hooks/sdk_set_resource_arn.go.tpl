@@ -0,0 +1 @@
+    rm.setResourceARN(ko)
\ No newline at end of file
@@ -0,0 +1,5 @@
+    if delta.DifferentAt("Spec.ManageInvokePermission") {
+        if err := rm.syncInvokePermission(ctx, desired.ko); err != nil {
+            return nil, err
+        }
+    }
\ No newline at end of file
@@ -1,3 +1,11 @@
     if latest.ko.Status.VPCLinkStatus != nil && *latest.ko.Status.VPCLinkStatus != string(svcsdktypes.VpcLinkStatusAvailable) {
         return nil, waitForAvailableRequeue
+    }
+    if delta.DifferentAt("Spec.Tags") {
+        if err := rm.syncTags(ctx, desired, latest); err != nil {
+            return nil, err
+        }
+    }
+    if !delta.DifferentExcept("Spec.Tags") {
+        return desired, nil
     }
\ No newline at end of file
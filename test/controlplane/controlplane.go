@@ -0,0 +1,320 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package controlplane serves a local stand-in for the API Gateway v2 control
+// plane over HTTP, for tests that run the controller binary against envtest.
+// It speaks the REST-JSON protocol of the apigatewayv2 service, so that the
+// controller can be pointed at it with --aws-endpoint-url, and answers the STS
+// GetCallerIdentity call the controller makes at startup when pointed at it
+// with --aws-identity-endpoint-url. The requests are served by an
+// sdkapi.Client, usually the in-memory one of the fake package.
+package controlplane
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi"
+)
+
+// DefaultAccountID is the AWS account the callers are authenticated as.
+const DefaultAccountID = "123456789012"
+
+// statusCodes are the HTTP status codes of the API Gateway v2 errors.
+var statusCodes = map[string]int{
+	"AccessDeniedException":     http.StatusForbidden,
+	"BadRequestException":       http.StatusBadRequest,
+	"ConflictException":         http.StatusConflict,
+	"NotFoundException":         http.StatusNotFound,
+	"TooManyRequestsException":  http.StatusTooManyRequests,
+	"UnknownOperationException": http.StatusNotFound,
+}
+
+// Handler is an http.Handler serving the API Gateway v2 operations of an
+// sdkapi.Client.
+type Handler struct {
+	// AccountID is the AWS account returned by GetCallerIdentity.
+	AccountID string
+
+	client     sdkapi.Client
+	operations []operation
+}
+
+// operation is a route bound to the method of the client serving it.
+type operation struct {
+	route
+	pattern *regexp.Regexp
+	call    reflect.Value
+}
+
+// New returns a Handler serving the operations of client.
+func New(client sdkapi.Client) *Handler {
+	h := &Handler{
+		AccountID: DefaultAccountID,
+		client:    client,
+	}
+	for _, r := range routes {
+		h.operations = append(h.operations, operation{
+			route:   r,
+			pattern: r.compile(),
+			call:    reflect.ValueOf(client).MethodByName(r.op),
+		})
+	}
+	return h
+}
+
+// ServeHTTP decodes the input of the operation from the request, calls the
+// client and encodes its output or error like API Gateway does.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/" {
+		h.serveSTS(w, r)
+		return
+	}
+	op, labels := h.match(r)
+	if op == nil {
+		writeError(w, "UnknownOperationException", fmt.Sprintf("no operation for %s %s", r.Method, r.URL.Path))
+		return
+	}
+	in := reflect.New(op.call.Type().In(1).Elem())
+	if err := decodeInput(in, r, labels); err != nil {
+		writeError(w, "BadRequestException", err.Error())
+		return
+	}
+	res := op.call.Call([]reflect.Value{reflect.ValueOf(r.Context()), in})
+	if err, _ := res[1].Interface().(error); err != nil {
+		code, msg := "InternalServerError", err.Error()
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			code, msg = apiErr.ErrorCode(), apiErr.ErrorMessage()
+		}
+		writeError(w, code, msg)
+		return
+	}
+	writeOutput(w, res[0].Elem())
+}
+
+// match returns the operation of the request and the unescaped values of
+// the labels in its path.
+func (h *Handler) match(r *http.Request) (*operation, map[string]string) {
+	path := r.URL.EscapedPath()
+	for i := range h.operations {
+		op := &h.operations[i]
+		if op.method != r.Method {
+			continue
+		}
+		m := op.pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		labels := map[string]string{}
+		for j, name := range op.pattern.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			value, err := url.PathUnescape(m[j])
+			if err != nil {
+				return nil, nil
+			}
+			labels[name] = value
+		}
+		return op, labels
+	}
+	return nil, nil
+}
+
+// decodeInput fills the input struct pointed at by in from the JSON body,
+// the path labels and the query string of the request.
+func decodeInput(in reflect.Value, r *http.Request, labels map[string]string) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) > 0 {
+		// encoding/json matches the camel case members of the body to the
+		// fields of the input case-insensitively.
+		if err := json.Unmarshal(body, in.Interface()); err != nil {
+			return fmt.Errorf("invalid request body: %v", err)
+		}
+	}
+	v := in.Elem()
+	for name, value := range labels {
+		if err := setField(v.FieldByName(name), []string{value}); err != nil {
+			return fmt.Errorf("invalid %s: %v", name, err)
+		}
+	}
+	for key, values := range r.URL.Query() {
+		f := v.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, key) })
+		if err := setField(f, values); err != nil {
+			return fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// setField sets an input field bound to a path label or query parameter.
+func setField(f reflect.Value, values []string) error {
+	if !f.IsValid() {
+		return errors.New("unknown parameter")
+	}
+	if f.Kind() == reflect.Slice {
+		for _, value := range values {
+			e := reflect.New(f.Type().Elem()).Elem()
+			if err := setField(e, []string{value}); err != nil {
+				return err
+			}
+			f.Set(reflect.Append(f, e))
+		}
+		return nil
+	}
+	if f.Kind() == reflect.Ptr {
+		f.Set(reflect.New(f.Type().Elem()))
+		f = f.Elem()
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(values[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(values[0], 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(i)
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// writeOutput encodes the output struct out as the response. ExportApi is the
+// only operation whose output is the raw body.
+func writeOutput(w http.ResponseWriter, out reflect.Value) {
+	if f := out.FieldByName("Body"); f.IsValid() && f.Kind() == reflect.Slice {
+		body := f.Bytes()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(body)
+		return
+	}
+	v, _ := encode(out)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError encodes an API Gateway error. The SDK reads the error code
+// from the X-Amzn-ErrorType header.
+func writeError(w http.ResponseWriter, code string, msg string) {
+	status, ok := statusCodes[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Amzn-ErrorType", code)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": msg})
+}
+
+// encode returns the JSON value of an output field, with the members named
+// in camel case like in the API Gateway responses. Returns false for unset
+// fields, which are omitted.
+func encode(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false
+		}
+		return encode(v.Elem())
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339Nano), true
+		}
+		m := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() || f.Name == "ResultMetadata" {
+				continue
+			}
+			if fv, ok := encode(v.Field(i)); ok {
+				m[memberName(f.Name)] = fv
+			}
+		}
+		return m, true
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, false
+		}
+		m := map[string]interface{}{}
+		iter := v.MapRange()
+		for iter.Next() {
+			if fv, ok := encode(iter.Value()); ok {
+				m[iter.Key().String()] = fv
+			}
+		}
+		return m, true
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, false
+		}
+		l := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			fv, _ := encode(v.Index(i))
+			l = append(l, fv)
+		}
+		return l, true
+	case reflect.String:
+		// Unset enum values are empty strings.
+		return v.String(), v.String() != ""
+	default:
+		return v.Interface(), true
+	}
+}
+
+// memberName returns the JSON member name of an output field, e.g. "apiId"
+// for ApiId.
+func memberName(field string) string {
+	r, size := utf8.DecodeRuneInString(field)
+	return string(unicode.ToLower(r)) + field[size:]
+}
+
+// serveSTS answers the GetCallerIdentity calls of the STS query protocol.
+func (h *Handler) serveSTS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/xml")
+	if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "GetCallerIdentity" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>InvalidAction</Code>`+
+			`<Message>only GetCallerIdentity is supported</Message></Error></ErrorResponse>`)
+		return
+	}
+	fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">`+
+		`<GetCallerIdentityResult><Arn>arn:aws:iam::%[1]s:user/controlplane</Arn>`+
+		`<UserId>AIDACONTROLPLANE</UserId><Account>%[1]s</Account></GetCallerIdentityResult>`+
+		`<ResponseMetadata><RequestId>controlplane</RequestId></ResponseMetadata>`+
+		`</GetCallerIdentityResponse>`, h.AccountID)
+}
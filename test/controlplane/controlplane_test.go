@@ -0,0 +1,274 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+)
+
+const openAPI = `{
+  "openapi": "3.0.1",
+  "info": {"title": "pets", "version": "1.0"},
+  "paths": {"/pets": {"get": {}, "post": {}}}
+}`
+
+// newTestClient returns an SDK client for a control plane serving c.
+func newTestClient(t *testing.T, c *fake.Client) *svcsdk.Client {
+	t.Helper()
+	srv := httptest.NewServer(New(c))
+	t.Cleanup(srv.Close)
+	return svcsdk.New(svcsdk.Options{
+		Region:       "us-west-2",
+		BaseEndpoint: aws.String(srv.URL),
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	ops := map[string]bool{}
+	for _, r := range routes {
+		if ops[r.op] {
+			t.Errorf("duplicate route for %s", r.op)
+		}
+		ops[r.op] = true
+	}
+	client := reflect.TypeOf((*sdkapi.Client)(nil)).Elem()
+	for i := 0; i < client.NumMethod(); i++ {
+		if name := client.Method(i).Name; !ops[name] {
+			t.Errorf("no route for %s", name)
+		}
+	}
+	if len(ops) != client.NumMethod() {
+		t.Errorf("%d routes, want %d", len(ops), client.NumMethod())
+	}
+}
+
+func TestHandler_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := fake.New()
+	sdk := newTestClient(t, c)
+
+	created, err := sdk.CreateApi(ctx, &svcsdk.CreateApiInput{
+		Name:         aws.String("pets"),
+		ProtocolType: svcsdktypes.ProtocolTypeHttp,
+		CorsConfiguration: &svcsdktypes.Cors{
+			AllowOrigins: []string{"https://example.com"},
+			MaxAge:       aws.Int32(300),
+		},
+		Tags: map[string]string{"team": "pets"},
+	})
+	if err != nil {
+		t.Fatalf("CreateApi() error = %v", err)
+	}
+	got, err := sdk.GetApi(ctx, &svcsdk.GetApiInput{ApiId: created.ApiId})
+	if err != nil {
+		t.Fatalf("GetApi() error = %v", err)
+	}
+	want, _ := c.GetApi(ctx, &svcsdk.GetApiInput{ApiId: created.ApiId})
+	if aws.ToString(got.Name) != "pets" || got.ProtocolType != svcsdktypes.ProtocolTypeHttp {
+		t.Errorf("GetApi() = %s %s, want pets HTTP", aws.ToString(got.Name), got.ProtocolType)
+	}
+	if !reflect.DeepEqual(got.CorsConfiguration, want.CorsConfiguration) {
+		t.Errorf("CorsConfiguration = %+v, want %+v", got.CorsConfiguration, want.CorsConfiguration)
+	}
+	if !got.CreatedDate.Equal(*want.CreatedDate) {
+		t.Errorf("CreatedDate = %v, want %v", got.CreatedDate, want.CreatedDate)
+	}
+	if !reflect.DeepEqual(got.Tags, want.Tags) {
+		t.Errorf("Tags = %v, want %v", got.Tags, want.Tags)
+	}
+
+	// Labels with reserved characters are escaped in the path.
+	route, err := sdk.CreateRoute(ctx, &svcsdk.CreateRouteInput{
+		ApiId:             created.ApiId,
+		RouteKey:          aws.String("GET /pets/{id}"),
+		RequestParameters: map[string]svcsdktypes.ParameterConstraints{"route.request.header.x-id": {Required: aws.Bool(true)}},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoute() error = %v", err)
+	}
+	if _, err := sdk.DeleteRouteRequestParameter(ctx, &svcsdk.DeleteRouteRequestParameterInput{
+		ApiId:               created.ApiId,
+		RouteId:             route.RouteId,
+		RequestParameterKey: aws.String("route.request.header.x-id"),
+	}); err != nil {
+		t.Fatalf("DeleteRouteRequestParameter() error = %v", err)
+	}
+	arn := "arn:aws:apigateway:us-west-2::/apis/" + *created.ApiId
+	if _, err := sdk.UntagResource(ctx, &svcsdk.UntagResourceInput{ResourceArn: &arn, TagKeys: []string{"team"}}); err != nil {
+		t.Fatalf("UntagResource() error = %v", err)
+	}
+	got, err = sdk.GetApi(ctx, &svcsdk.GetApiInput{ApiId: created.ApiId})
+	if err != nil {
+		t.Fatalf("GetApi() error = %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Errorf("Tags = %v, want none", got.Tags)
+	}
+
+	wantCalls := []string{"CreateApi", "GetApi", "GetApi", "CreateRoute", "DeleteRouteRequestParameter", "UntagResource", "GetApi"}
+	if calls := c.Calls(); !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+}
+
+func TestHandler_ImportExport(t *testing.T) {
+	ctx := context.Background()
+	c := fake.New()
+	sdk := newTestClient(t, c)
+
+	imported, err := sdk.ImportApi(ctx, &svcsdk.ImportApiInput{Body: aws.String(openAPI), FailOnWarnings: aws.Bool(true)})
+	if err != nil {
+		t.Fatalf("ImportApi() error = %v", err)
+	}
+	routes, err := sdk.GetRoutes(ctx, &svcsdk.GetRoutesInput{ApiId: imported.ApiId})
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if len(routes.Items) != 2 {
+		t.Errorf("GetRoutes() = %d routes, want 2", len(routes.Items))
+	}
+	exported, err := sdk.ExportApi(ctx, &svcsdk.ExportApiInput{
+		ApiId:         imported.ApiId,
+		Specification: aws.String("OAS30"),
+		OutputType:    aws.String("JSON"),
+	})
+	if err != nil {
+		t.Fatalf("ExportApi() error = %v", err)
+	}
+	if !strings.Contains(string(exported.Body), `"/pets"`) {
+		t.Errorf("ExportApi() = %s, want the /pets path", exported.Body)
+	}
+}
+
+func TestHandler_Pagination(t *testing.T) {
+	ctx := context.Background()
+	c := fake.New()
+	c.PageSize = 2
+	sdk := newTestClient(t, c)
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := sdk.CreateVpcLink(ctx, &svcsdk.CreateVpcLinkInput{
+			Name:      aws.String(name),
+			SubnetIds: []string{"subnet-1"},
+		}); err != nil {
+			t.Fatalf("CreateVpcLink() error = %v", err)
+		}
+	}
+
+	var names []string
+	in := &svcsdk.GetVpcLinksInput{MaxResults: aws.String("2")}
+	for {
+		out, err := sdk.GetVpcLinks(ctx, in)
+		if err != nil {
+			t.Fatalf("GetVpcLinks() error = %v", err)
+		}
+		for _, l := range out.Items {
+			names = append(names, aws.ToString(l.Name))
+		}
+		if out.NextToken == nil {
+			break
+		}
+		in.NextToken = out.NextToken
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := fake.New()
+	sdk := newTestClient(t, c)
+	api, err := sdk.CreateApi(ctx, &svcsdk.CreateApiInput{Name: aws.String("pets"), ProtocolType: svcsdktypes.ProtocolTypeHttp})
+	if err != nil {
+		t.Fatalf("CreateApi() error = %v", err)
+	}
+	if _, err := sdk.CreateStage(ctx, &svcsdk.CreateStageInput{ApiId: api.ApiId, StageName: aws.String("prod")}); err != nil {
+		t.Fatalf("CreateStage() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want interface{}
+	}{
+		{
+			name: "not found",
+			call: func() error {
+				_, err := sdk.GetApi(ctx, &svcsdk.GetApiInput{ApiId: aws.String("missing")})
+				return err
+			},
+			want: &svcsdktypes.NotFoundException{},
+		},
+		{
+			name: "conflict",
+			call: func() error {
+				_, err := sdk.CreateStage(ctx, &svcsdk.CreateStageInput{ApiId: api.ApiId, StageName: aws.String("prod")})
+				return err
+			},
+			want: &svcsdktypes.ConflictException{},
+		},
+		{
+			name: "injected",
+			call: func() error {
+				c.Fail("GetApi", &svcsdktypes.TooManyRequestsException{Message: aws.String("slow down")})
+				_, err := sdk.GetApi(ctx, &svcsdk.GetApiInput{ApiId: api.ApiId})
+				return err
+			},
+			want: &svcsdktypes.TooManyRequestsException{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			target := reflect.New(reflect.TypeOf(tt.want))
+			if !errors.As(err, target.Interface()) {
+				t.Errorf("error = %v, want %T", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_CallerIdentity(t *testing.T) {
+	srv := httptest.NewServer(New(fake.New()))
+	defer srv.Close()
+
+	resp, err := http.PostForm(srv.URL, url.Values{"Action": {"GetCallerIdentity"}, "Version": {"2011-06-15"}})
+	if err != nil {
+		t.Fatalf("PostForm() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<Account>"+DefaultAccountID+"</Account>") {
+		t.Errorf("GetCallerIdentity = %d %s, want the account", resp.StatusCode, body)
+	}
+}
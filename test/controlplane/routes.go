@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controlplane
+
+import (
+	"regexp"
+	"strings"
+)
+
+// route binds an operation of the API Gateway v2 client to the HTTP method
+// and URI template the REST protocol uses for it. The labels of the template
+// are the names of the input fields they are bound to.
+type route struct {
+	op     string
+	method string
+	path   string
+}
+
+// routes are the operations of sdkapi.Client.
+var routes = []route{
+	{"CreateApi", "POST", "/v2/apis"},
+	{"CreateApiMapping", "POST", "/v2/domainnames/{DomainName}/apimappings"},
+	{"CreateAuthorizer", "POST", "/v2/apis/{ApiId}/authorizers"},
+	{"CreateDeployment", "POST", "/v2/apis/{ApiId}/deployments"},
+	{"CreateDomainName", "POST", "/v2/domainnames"},
+	{"CreateIntegration", "POST", "/v2/apis/{ApiId}/integrations"},
+	{"CreateIntegrationResponse", "POST", "/v2/apis/{ApiId}/integrations/{IntegrationId}/integrationresponses"},
+	{"CreateModel", "POST", "/v2/apis/{ApiId}/models"},
+	{"CreateRoute", "POST", "/v2/apis/{ApiId}/routes"},
+	{"CreateRouteResponse", "POST", "/v2/apis/{ApiId}/routes/{RouteId}/routeresponses"},
+	{"CreateStage", "POST", "/v2/apis/{ApiId}/stages"},
+	{"CreateVpcLink", "POST", "/v2/vpclinks"},
+	{"DeleteApi", "DELETE", "/v2/apis/{ApiId}"},
+	{"DeleteApiMapping", "DELETE", "/v2/domainnames/{DomainName}/apimappings/{ApiMappingId}"},
+	{"DeleteAuthorizer", "DELETE", "/v2/apis/{ApiId}/authorizers/{AuthorizerId}"},
+	{"DeleteCorsConfiguration", "DELETE", "/v2/apis/{ApiId}/cors"},
+	{"DeleteDeployment", "DELETE", "/v2/apis/{ApiId}/deployments/{DeploymentId}"},
+	{"DeleteDomainName", "DELETE", "/v2/domainnames/{DomainName}"},
+	{"DeleteIntegration", "DELETE", "/v2/apis/{ApiId}/integrations/{IntegrationId}"},
+	{"DeleteIntegrationResponse", "DELETE", "/v2/apis/{ApiId}/integrations/{IntegrationId}/integrationresponses/{IntegrationResponseId}"},
+	{"DeleteModel", "DELETE", "/v2/apis/{ApiId}/models/{ModelId}"},
+	{"DeleteRoute", "DELETE", "/v2/apis/{ApiId}/routes/{RouteId}"},
+	{"DeleteRouteRequestParameter", "DELETE", "/v2/apis/{ApiId}/routes/{RouteId}/requestparameters/{RequestParameterKey}"},
+	{"DeleteRouteResponse", "DELETE", "/v2/apis/{ApiId}/routes/{RouteId}/routeresponses/{RouteResponseId}"},
+	{"DeleteRouteSettings", "DELETE", "/v2/apis/{ApiId}/stages/{StageName}/routesettings/{RouteKey}"},
+	{"DeleteStage", "DELETE", "/v2/apis/{ApiId}/stages/{StageName}"},
+	{"DeleteVpcLink", "DELETE", "/v2/vpclinks/{VpcLinkId}"},
+	{"ExportApi", "GET", "/v2/apis/{ApiId}/exports/{Specification}"},
+	{"GetApi", "GET", "/v2/apis/{ApiId}"},
+	{"GetApiMapping", "GET", "/v2/domainnames/{DomainName}/apimappings/{ApiMappingId}"},
+	{"GetApis", "GET", "/v2/apis"},
+	{"GetAuthorizer", "GET", "/v2/apis/{ApiId}/authorizers/{AuthorizerId}"},
+	{"GetDeployment", "GET", "/v2/apis/{ApiId}/deployments/{DeploymentId}"},
+	{"GetDomainName", "GET", "/v2/domainnames/{DomainName}"},
+	{"GetIntegration", "GET", "/v2/apis/{ApiId}/integrations/{IntegrationId}"},
+	{"GetIntegrationResponse", "GET", "/v2/apis/{ApiId}/integrations/{IntegrationId}/integrationresponses/{IntegrationResponseId}"},
+	{"GetModel", "GET", "/v2/apis/{ApiId}/models/{ModelId}"},
+	{"GetRoute", "GET", "/v2/apis/{ApiId}/routes/{RouteId}"},
+	{"GetRouteResponse", "GET", "/v2/apis/{ApiId}/routes/{RouteId}/routeresponses/{RouteResponseId}"},
+	{"GetRoutes", "GET", "/v2/apis/{ApiId}/routes"},
+	{"GetStage", "GET", "/v2/apis/{ApiId}/stages/{StageName}"},
+	{"GetVpcLink", "GET", "/v2/vpclinks/{VpcLinkId}"},
+	{"GetVpcLinks", "GET", "/v2/vpclinks"},
+	{"ImportApi", "PUT", "/v2/apis"},
+	{"ReimportApi", "PUT", "/v2/apis/{ApiId}"},
+	{"TagResource", "POST", "/v2/tags/{ResourceArn}"},
+	{"UntagResource", "DELETE", "/v2/tags/{ResourceArn}"},
+	{"UpdateApi", "PATCH", "/v2/apis/{ApiId}"},
+	{"UpdateApiMapping", "PATCH", "/v2/domainnames/{DomainName}/apimappings/{ApiMappingId}"},
+	{"UpdateAuthorizer", "PATCH", "/v2/apis/{ApiId}/authorizers/{AuthorizerId}"},
+	{"UpdateDeployment", "PATCH", "/v2/apis/{ApiId}/deployments/{DeploymentId}"},
+	{"UpdateDomainName", "PATCH", "/v2/domainnames/{DomainName}"},
+	{"UpdateIntegration", "PATCH", "/v2/apis/{ApiId}/integrations/{IntegrationId}"},
+	{"UpdateIntegrationResponse", "PATCH", "/v2/apis/{ApiId}/integrations/{IntegrationId}/integrationresponses/{IntegrationResponseId}"},
+	{"UpdateModel", "PATCH", "/v2/apis/{ApiId}/models/{ModelId}"},
+	{"UpdateRoute", "PATCH", "/v2/apis/{ApiId}/routes/{RouteId}"},
+	{"UpdateRouteResponse", "PATCH", "/v2/apis/{ApiId}/routes/{RouteId}/routeresponses/{RouteResponseId}"},
+	{"UpdateStage", "PATCH", "/v2/apis/{ApiId}/stages/{StageName}"},
+	{"UpdateVpcLink", "PATCH", "/v2/vpclinks/{VpcLinkId}"},
+}
+
+// labelRegex matches the labels of a URI template, e.g. "{ApiId}".
+var labelRegex = regexp.MustCompile(`\{([A-Za-z]+)\}`)
+
+// compile returns a regular expression matching the escaped paths of the
+// route, with a named group per label.
+func (r route) compile() *regexp.Regexp {
+	parts := labelRegex.Split(r.path, -1)
+	labels := labelRegex.FindAllStringSubmatch(r.path, -1)
+	var b strings.Builder
+	b.WriteString("^")
+	for i, part := range parts {
+		b.WriteString(regexp.QuoteMeta(part))
+		if i < len(labels) {
+			b.WriteString("(?P<" + labels[i][1] + ">[^/]+)")
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -22,6 +22,8 @@ REPLACEMENT_VALUES = {
     "DOMAIN_NAME": "ack-test-domain-name",
     "IMPORT_API_NAME": "ack-test-import-api",
     "IMPORT_API_TITLE": "ack-test-import-api",
+    "IMPORT_API_CONFIGMAP_NAME": "ack-test-import-api",
+    "EXPORT_API_CONFIGMAP_NAME": "ack-test-export-api",
     "API_ID": "api_id",
     "DOMAIN_NAME": "ack-test-domain-name",
     "INTEGRATION_NAME": "ack-test-integration",
@@ -45,5 +47,17 @@ REPLACEMENT_VALUES = {
     "REF_INTEGRATION_NAME": "ack-test-ref-integration",
     "REF_ROUTE_NAME": "ack-test-ref-route",
     "REF_STAGE_NAME": "ack-test-ref-stage",
-    "REF_STAGE_DESCRIPTION": "Stage for ack resource reference testing"
+    "REF_STAGE_DESCRIPTION": "Stage for ack resource reference testing",
+    "WEBSOCKET_API_NAME": "ack-test-websocket-api",
+    "WEBSOCKET_API_TITLE": "ack-test-websocket-api",
+    "MODEL_NAME": "ack-test-model",
+    "MODEL_TITLE": "acktestmodel",
+    "MODEL_DESCRIPTION": "ack-test-model",
+    "WEBSOCKET_INTEGRATION_NAME": "ack-test-ws-integration",
+    "INTEGRATION_RESPONSE_NAME": "ack-test-integration-response",
+    "TEMPLATE_SELECTION_EXPRESSION": "json",
+    "WEBSOCKET_ROUTE_NAME": "ack-test-ws-route",
+    "WEBSOCKET_ROUTE_KEY": "sendmessage",
+    "ROUTE_RESPONSE_NAME": "ack-test-route-response",
+    "MODEL_SELECTION_EXPRESSION": "json"
 }
@@ -25,6 +25,9 @@ STAGE_RESOURCE_PLURAL = 'stages'
 VPC_LINK_RESOURCE_PLURAL = 'vpclinks'
 API_MAPPING_RESOURCE_PLURAL = 'apimappings'
 DOMAIN_NAME_RESOURCE_PLURAL = 'domainnames'
+MODEL_RESOURCE_PLURAL = 'models'
+INTEGRATION_RESPONSE_RESOURCE_PLURAL = 'integrationresponses'
+ROUTE_RESPONSE_RESOURCE_PLURAL = 'routeresponses'
 
 
 def api_ref_and_data(api_resource_name: str, replacement_values: dict, file_name: str = "httpapi"):
@@ -141,6 +144,56 @@ def domain_name_ref_and_data(domain_name_resource_name: str, replacement_values:
     )
     return ref, resource_data
 
+def websocket_api_ref_and_data(api_resource_name: str, replacement_values: dict, file_name: str = "websocket-api"):
+    ref = resource.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, API_RESOURCE_PLURAL,
+        api_resource_name, namespace="default",
+    )
+
+    resource_data = load_apigatewayv2_resource(
+        file_name,
+        additional_replacements=replacement_values,
+    )
+    return ref, resource_data
+
+def model_ref_and_data(model_resource_name: str, replacement_values: dict, file_name: str = "model"):
+    ref = resource.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, MODEL_RESOURCE_PLURAL,
+        model_resource_name, namespace="default",
+    )
+
+    resource_data = load_apigatewayv2_resource(
+        file_name,
+        additional_replacements=replacement_values,
+    )
+    return ref, resource_data
+
+def integration_response_ref_and_data(integration_response_resource_name: str, replacement_values: dict,
+                                      file_name: str = "integration-response"):
+    ref = resource.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, INTEGRATION_RESPONSE_RESOURCE_PLURAL,
+        integration_response_resource_name, namespace="default",
+    )
+
+    resource_data = load_apigatewayv2_resource(
+        file_name,
+        additional_replacements=replacement_values,
+    )
+    return ref, resource_data
+
+def route_response_ref_and_data(route_response_resource_name: str, replacement_values: dict,
+                                file_name: str = "route-response"):
+    ref = resource.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, ROUTE_RESPONSE_RESOURCE_PLURAL,
+        route_response_resource_name, namespace="default",
+    )
+
+    resource_data = load_apigatewayv2_resource(
+        file_name,
+        additional_replacements=replacement_values,
+    )
+    return ref, resource_data
+
 
 class ApiGatewayValidator:
 
@@ -171,6 +224,20 @@ class ApiGatewayValidator:
         aws_res = self.apigatewayv2_client.get_vpc_link(VpcLinkId=vpc_link_id)
         assert aws_res is not None
 
+    def assert_model_is_present(self, api_id: str, model_id: str):
+        aws_res = self.apigatewayv2_client.get_model(ApiId=api_id, ModelId=model_id)
+        assert aws_res is not None
+
+    def assert_integration_response_is_present(self, api_id: str, integration_id: str, integration_response_id: str):
+        aws_res = self.apigatewayv2_client.get_integration_response(ApiId=api_id, IntegrationId=integration_id,
+                                                                    IntegrationResponseId=integration_response_id)
+        assert aws_res is not None
+
+    def assert_route_response_is_present(self, api_id: str, route_id: str, route_response_id: str):
+        aws_res = self.apigatewayv2_client.get_route_response(ApiId=api_id, RouteId=route_id,
+                                                              RouteResponseId=route_response_id)
+        assert aws_res is not None
+
     def assert_api_is_deleted(self, api_id: str):
         res_found = False
         try:
@@ -231,6 +298,38 @@ class ApiGatewayValidator:
 
         assert res_found is False
 
+    def assert_model_is_deleted(self, api_id: str, model_id: str):
+        res_found = False
+        try:
+            self.apigatewayv2_client.get_model(ApiId=api_id, ModelId=model_id)
+            res_found = True
+        except self.apigatewayv2_client.exceptions.NotFoundException:
+            pass
+
+        assert res_found is False
+
+    def assert_integration_response_is_deleted(self, api_id: str, integration_id: str, integration_response_id: str):
+        res_found = False
+        try:
+            self.apigatewayv2_client.get_integration_response(ApiId=api_id, IntegrationId=integration_id,
+                                                              IntegrationResponseId=integration_response_id)
+            res_found = True
+        except self.apigatewayv2_client.exceptions.NotFoundException:
+            pass
+
+        assert res_found is False
+
+    def assert_route_response_is_deleted(self, api_id: str, route_id: str, route_response_id: str):
+        res_found = False
+        try:
+            self.apigatewayv2_client.get_route_response(ApiId=api_id, RouteId=route_id,
+                                                        RouteResponseId=route_response_id)
+            res_found = True
+        except self.apigatewayv2_client.exceptions.NotFoundException:
+            pass
+
+        assert res_found is False
+
     def assert_api_name(self, api_id, expected_api_name):
         aws_res = self.apigatewayv2_client.get_api(ApiId=api_id)
         assert aws_res is not None
@@ -260,3 +359,35 @@ class ApiGatewayValidator:
         aws_res = self.apigatewayv2_client.get_vpc_link(VpcLinkId=vpc_link_id)
         assert aws_res is not None
         assert aws_res['Name'] == expected_vpc_link_name
+
+    def assert_model_description(self, api_id, model_id, expected_description):
+        aws_res = self.apigatewayv2_client.get_model(ApiId=api_id, ModelId=model_id)
+        assert aws_res is not None
+        assert aws_res['Description'] == expected_description
+
+    def assert_integration_response_template_selection_expression(self, api_id, integration_id,
+                                                                  integration_response_id, expected_expression):
+        aws_res = self.apigatewayv2_client.get_integration_response(ApiId=api_id, IntegrationId=integration_id,
+                                                                    IntegrationResponseId=integration_response_id)
+        assert aws_res is not None
+        assert aws_res['TemplateSelectionExpression'] == expected_expression
+
+    def assert_route_response_models(self, api_id, route_id, route_response_id, expected_models):
+        aws_res = self.apigatewayv2_client.get_route_response(ApiId=api_id, RouteId=route_id,
+                                                              RouteResponseId=route_response_id)
+        assert aws_res is not None
+        assert aws_res['ResponseModels'] == expected_models
+
+    def assert_route_response_model_selection_expression(self, api_id, route_id, route_response_id,
+                                                         expected_expression):
+        aws_res = self.apigatewayv2_client.get_route_response(ApiId=api_id, RouteId=route_id,
+                                                              RouteResponseId=route_response_id)
+        assert aws_res is not None
+        assert aws_res['ModelSelectionExpression'] == expected_expression
+
+    def assert_api_tags(self, api_id, expected_tags):
+        aws_res = self.apigatewayv2_client.get_api(ApiId=api_id)
+        assert aws_res is not None
+        # ignore the tags added by the controller itself
+        user_tags = {k: v for k, v in aws_res.get('Tags', {}).items() if not k.startswith('services.k8s.aws/')}
+        assert user_tags == expected_tags
@@ -17,9 +17,12 @@
 import logging
 import time
 
+import json
+
 import boto3
 import pytest
 import requests
+from kubernetes import client as k8s_client
 
 from acktest.k8s import resource as k8s
 from acktest.k8s import condition
@@ -244,6 +247,87 @@ class TestApiGatewayV2:
         # HTTP Api should no longer appear in Amazon API Gateway
         apigw_validator.assert_api_is_deleted(api_id=api_id)
 
+    def test_update_httpapi_tags(self):
+        test_data = REPLACEMENT_VALUES.copy()
+        api_name = random_suffix_name("ack-test-tags", 25)
+        test_data['API_NAME'] = api_name
+        test_data['API_TITLE'] = api_name
+        api_ref, api_data = helper.api_ref_and_data(api_resource_name=api_name,
+                                                    replacement_values=test_data)
+        api_data['spec']['tags'] = {'team': 'ack', 'env': 'dev'}
+        logging.debug(f"http api resource. name: {api_name}, data: {api_data}")
+
+        k8s.create_custom_resource(api_ref, api_data)
+        time.sleep(CREATE_API_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(api_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(api_ref)
+        assert cr is not None
+
+        api_id = cr['status']['apiID']
+        apigw_validator.assert_api_tags(api_id=api_id, expected_tags={'team': 'ack', 'env': 'dev'})
+
+        # add, change and remove tags at once
+        updated_tags = {'env': 'prod', 'owner': 'platform'}
+        k8s.patch_custom_resource(api_ref, {'spec': {'tags': {'team': None, 'env': 'prod', 'owner': 'platform'}}})
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        condition.assert_synced(api_ref)
+        apigw_validator.assert_api_tags(api_id=api_id, expected_tags=updated_tags)
+
+        k8s.delete_custom_resource(api_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(api_ref)
+        apigw_validator.assert_api_is_deleted(api_id=api_id)
+
+    def test_adopt_httpapi_by_name(self):
+        apigw_client = boto3.client('apigatewayv2')
+        api_title = random_suffix_name("ack-test-adopt", 25)
+        api_id = apigw_client.create_api(Name=api_title, ProtocolType='HTTP')['ApiId']
+        duplicate_id = None
+
+        test_data = REPLACEMENT_VALUES.copy()
+        api_name = random_suffix_name("ack-test-adoptapi", 25)
+        test_data['API_NAME'] = api_name
+        test_data['API_TITLE'] = api_title
+        api_ref, api_data = helper.api_ref_and_data(api_resource_name=api_name,
+                                                    replacement_values=test_data,
+                                                    file_name="adopt_api_by_name")
+        try:
+            # a single API with the name and protocol is adopted
+            k8s.create_custom_resource(api_ref, api_data)
+            time.sleep(CREATE_WAIT_AFTER_SECONDS)
+            assert k8s.wait_on_condition(api_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+            cr = k8s.get_resource(api_ref)
+            assert cr['status']['apiID'] == api_id
+            assert cr['spec']['name'] == api_title
+
+            # adoption fails when the name and protocol match several APIs
+            duplicate_id = apigw_client.create_api(Name=api_title, ProtocolType='HTTP')['ApiId']
+            ambiguous_name = random_suffix_name("ack-test-adoptapi", 25)
+            test_data['API_NAME'] = ambiguous_name
+            ambiguous_ref, ambiguous_data = helper.api_ref_and_data(api_resource_name=ambiguous_name,
+                                                                    replacement_values=test_data,
+                                                                    file_name="adopt_api_by_name")
+            k8s.create_custom_resource(ambiguous_ref, ambiguous_data)
+            time.sleep(CREATE_WAIT_AFTER_SECONDS)
+            assert k8s.wait_on_condition(ambiguous_ref, "ACK.Terminal", "True", wait_periods=10)
+            terminal = k8s.get_resource_condition(ambiguous_ref, condition.CONDITION_TYPE_TERMINAL)
+            assert api_id in terminal['message'] and duplicate_id in terminal['message']
+            k8s.delete_custom_resource(ambiguous_ref)
+
+            # the adopted API is deleted with the resource
+            k8s.delete_custom_resource(api_ref)
+            time.sleep(DELETE_WAIT_AFTER_SECONDS)
+            apigw_validator.assert_api_is_deleted(api_id=api_id)
+        finally:
+            for leftover in (api_id, duplicate_id):
+                if leftover is not None:
+                    try:
+                        apigw_client.delete_api(ApiId=leftover)
+                    except apigw_client.exceptions.NotFoundException:
+                        pass
+
     def test_crud_httpapi_using_import(self):
         test_data = REPLACEMENT_VALUES.copy()
         api_name = random_suffix_name("ack-test-importapi", 25)
@@ -298,6 +382,152 @@ class TestApiGatewayV2:
         # HTTP Api should no longer appear in Amazon API Gateway
         apigw_validator.assert_api_is_deleted(api_id=api_id)
 
+    def test_crud_httpapi_using_import_body_from(self):
+        test_data = REPLACEMENT_VALUES.copy()
+        api_name = random_suffix_name("ack-test-bodyfrom", 25)
+        test_data['IMPORT_API_NAME'] = api_name
+        test_data['IMPORT_API_CONFIGMAP_NAME'] = api_name
+        api_ref, api_data = helper.import_api_ref_and_data(api_resource_name=api_name,
+                                                           replacement_values=test_data,
+                                                           file_name="import_api_body_from")
+        logging.debug(f"imported http api resource. name: {api_name}, data: {api_data}")
+
+        def openapi_body(title):
+            return json.dumps({
+                "openapi": "3.0.1",
+                "info": {"title": title, "version": "v1"},
+                "paths": {
+                    "/": {
+                        "get": {
+                            "x-amazon-apigateway-integration": {
+                                "uri": "http://example.com",
+                                "httpMethod": "GET",
+                                "type": "HTTP_PROXY",
+                                "payloadFormatVersion": "1.0",
+                            }
+                        }
+                    }
+                },
+            })
+
+        core_v1 = k8s_client.CoreV1Api(k8s._get_k8s_api_client())
+        core_v1.create_namespaced_config_map("default", k8s_client.V1ConfigMap(
+            metadata=k8s_client.V1ObjectMeta(name=api_name),
+            data={"openapi.json": openapi_body(api_name)},
+        ))
+
+        # test create
+        k8s.create_custom_resource(api_ref, api_data)
+        time.sleep(CREATE_API_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(api_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(api_ref)
+        assert cr is not None
+        assert cr['status']['bodyHash'] is not None
+        # the resolved body must not be written back into the spec
+        assert 'body' not in cr['spec']
+
+        api_id = cr['status']['apiID']
+        apigw_validator.assert_api_name(api_id=api_id, expected_api_name=api_name)
+
+        # editing the ConfigMap reimports the API
+        updated_api_title = 'updated-' + api_name
+        core_v1.patch_namespaced_config_map(api_name, "default", {
+            "data": {"openapi.json": openapi_body(updated_api_title)},
+        })
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        condition.assert_synced(api_ref)
+        apigw_validator.assert_api_name(api_id=api_id, expected_api_name=updated_api_title)
+        updated_cr = k8s.get_resource(api_ref)
+        assert updated_cr['status']['bodyHash'] != cr['status']['bodyHash']
+
+        # test delete
+        k8s.delete_custom_resource(api_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(api_ref)
+        apigw_validator.assert_api_is_deleted(api_id=api_id)
+        core_v1.delete_namespaced_config_map(api_name, "default")
+
+    def test_httpapi_using_import_invalid_body(self):
+        test_data = REPLACEMENT_VALUES.copy()
+        api_name = random_suffix_name("ack-test-invalidapi", 25)
+        test_data['IMPORT_API_NAME'] = api_name
+        test_data['IMPORT_API_TITLE'] = api_name
+        api_ref, api_data = helper.import_api_ref_and_data(api_resource_name=api_name,
+                                                           replacement_values=test_data,
+                                                           file_name="import_api_invalid")
+        logging.debug(f"invalid imported http api resource. name: {api_name}, data: {api_data}")
+
+        k8s.create_custom_resource(api_ref, api_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(api_ref, "ACK.Terminal", "True", wait_periods=10)
+
+        # the definition is rejected before reaching API Gateway
+        cr = k8s.get_resource(api_ref)
+        assert 'apiID' not in cr['status']
+        terminal = k8s.get_resource_condition(api_ref, "ACK.Terminal")
+        assert 'spec.body.paths[/pets/{proxy+}/owner]' in terminal['message']
+        assert 'x-amazon-apigateway-integration.type' in terminal['message']
+
+        k8s.delete_custom_resource(api_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(api_ref)
+
+    def test_crud_httpapi_using_import_export(self):
+        test_data = REPLACEMENT_VALUES.copy()
+        api_name = random_suffix_name("ack-test-export", 25)
+        test_data['IMPORT_API_NAME'] = api_name
+        test_data['IMPORT_API_TITLE'] = api_name
+        test_data['EXPORT_API_CONFIGMAP_NAME'] = api_name
+        api_ref, api_data = helper.import_api_ref_and_data(api_resource_name=api_name,
+                                                           replacement_values=test_data,
+                                                           file_name="import_api_export")
+        logging.debug(f"exported http api resource. name: {api_name}, data: {api_data}")
+
+        core_v1 = k8s_client.CoreV1Api(k8s._get_k8s_api_client())
+
+        def exported_definition():
+            cm = core_v1.read_namespaced_config_map(api_name, "default")
+            return cm, json.loads(cm.data["openapi.json"])
+
+        # test create
+        k8s.create_custom_resource(api_ref, api_data)
+        time.sleep(CREATE_API_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(api_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(api_ref)
+        assert cr is not None
+        api_id = cr['status']['apiID']
+
+        cm, definition = exported_definition()
+        assert definition['info']['title'] == api_name
+        assert cr['status']['exportHash'] == \
+            cm.metadata.annotations['apigatewayv2.services.k8s.aws/export-hash']
+        assert cm.metadata.owner_references[0].name == api_name
+
+        # test update, the export follows the reimported definition
+        updated_api_title = 'updated-' + api_name
+        test_data['IMPORT_API_TITLE'] = updated_api_title
+        updated_api_resource_data = load_apigatewayv2_resource(
+            "import_api_export",
+            additional_replacements=test_data,
+        )
+        k8s.patch_custom_resource(api_ref, updated_api_resource_data)
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+        condition.assert_synced(api_ref)
+
+        _, definition = exported_definition()
+        assert definition['info']['title'] == updated_api_title
+        updated_cr = k8s.get_resource(api_ref)
+        assert updated_cr['status']['exportHash'] != cr['status']['exportHash']
+
+        # test delete, the ConfigMap is garbage collected with the API
+        k8s.delete_custom_resource(api_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(api_ref)
+        apigw_validator.assert_api_is_deleted(api_id=api_id)
+
     def test_crud_integration(self, api_resource):
         api_ref, api_cr = api_resource
         api_id = api_cr['status']['apiID']
@@ -416,6 +646,87 @@ class TestApiGatewayV2:
         # HTTP Api authorizer should no longer appear in Amazon API Gateway
         apigw_validator.assert_authorizer_is_deleted(api_id=api_id, authorizer_id=authorizer_id)
 
+    def test_authorizer_managed_invoke_permission(self, api_resource):
+        api_ref, api_cr = api_resource
+        api_id = api_cr['status']['apiID']
+        test_data = REPLACEMENT_VALUES.copy()
+        authorizer_name = random_suffix_name("ack-test-authorizer", 25)
+        test_data['AUTHORIZER_NAME'] = authorizer_name
+        test_data['AUTHORIZER_TITLE'] = authorizer_name
+        test_data['API_ID'] = api_id
+        function_arn = get_bootstrap_resources().AuthorizerFunctionArn
+        test_data['AUTHORIZER_URI'] = f'arn:aws:apigateway:{get_region()}:lambda:path/2015-03-31/functions/{function_arn}/invocations'
+        authorizer_ref, authorizer_data = helper.authorizer_ref_and_data(authorizer_resource_name=authorizer_name,
+                                                                         replacement_values=test_data,
+                                                                         file_name="authorizer_invoke_permission")
+        logging.debug(f"http api authorizer resource. name: {authorizer_name}, data: {authorizer_data}")
+
+        lambda_client = boto3.client("lambda")
+        function_name = get_bootstrap_resources().AuthorizerFunctionName
+
+        def permission_statement(statement_id):
+            try:
+                policy = json.loads(lambda_client.get_policy(FunctionName=function_name)['Policy'])
+            except lambda_client.exceptions.ResourceNotFoundException:
+                return None
+            return next((s for s in policy['Statement'] if s['Sid'] == statement_id), None)
+
+        # test create, the permission is granted on the authorizer function
+        k8s.create_custom_resource(authorizer_ref, authorizer_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(authorizer_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(authorizer_ref)
+        assert cr is not None
+        authorizer_id = cr['status']['authorizerID']
+        assert cr['status']['invokePermissionFunctionARN'] == function_arn
+
+        statement_id = f'apigatewayv2-authorizer-{authorizer_id}'
+        statement = permission_statement(statement_id)
+        assert statement is not None
+        assert statement['Condition']['ArnLike']['AWS:SourceArn'] == \
+            f'arn:aws:execute-api:{get_region()}:{get_account_id()}:{api_id}/authorizers/{authorizer_id}'
+
+        # test delete, the permission is removed with the authorizer
+        k8s.delete_custom_resource(authorizer_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(authorizer_ref)
+        apigw_validator.assert_authorizer_is_deleted(api_id=api_id, authorizer_id=authorizer_id)
+        assert permission_statement(statement_id) is None
+
+    def test_route_key_validation(self, api_resource, route_resource):
+        api_ref, api_cr = api_resource
+        api_id = api_cr['status']['apiID']
+        existing_route_ref, existing_route_cr = route_resource
+        test_data = test_resource_values.copy()
+
+        def create_route(route_key):
+            route_name = random_suffix_name("ack-test-route", 25)
+            test_data['ROUTE_NAME'] = route_name
+            test_data['ROUTE_KEY'] = route_key
+            route_ref, route_data = helper.route_ref_and_data(route_resource_name=route_name,
+                                                              replacement_values=test_data)
+            k8s.create_custom_resource(route_ref, route_data)
+            time.sleep(CREATE_WAIT_AFTER_SECONDS)
+            assert k8s.wait_on_condition(route_ref, "ACK.Terminal", "True", wait_periods=10)
+            assert 'routeID' not in k8s.get_resource(route_ref)['status']
+            return route_ref, k8s.get_resource_condition(route_ref, "ACK.Terminal")
+
+        # a malformed route key is rejected before reaching API Gateway
+        invalid_route_ref, terminal = create_route('GET/pets')
+        assert 'spec.routeKey' in terminal['message']
+
+        # so is the route key of another Route of the same API
+        duplicate_route_ref, terminal = create_route(existing_route_cr['spec']['routeKey'])
+        assert existing_route_ref.name in terminal['message']
+        assert api_id in terminal['message']
+
+        for route_ref in (invalid_route_ref, duplicate_route_ref):
+            k8s.delete_custom_resource(route_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(invalid_route_ref)
+        assert not k8s.get_resource_exists(duplicate_route_ref)
+
     def test_crud_route(self, api_resource, integration_resource, authorizer_resource):
         api_ref, api_cr = api_resource
         api_id = api_cr['status']['apiID']
@@ -531,6 +842,12 @@ class TestApiGatewayV2:
             expected_description=updated_description
         )
 
+        # the stage name cannot be changed once set
+        with pytest.raises(k8s_client.exceptions.ApiException) as exc_info:
+            k8s.patch_custom_resource(stage_ref, {'spec': {'stageName': 'renamed-' + stage_name}})
+        assert exc_info.value.status == 422
+        assert 'Value is immutable once set' in exc_info.value.body
+
         # test delete
         k8s.delete_custom_resource(stage_ref)
         time.sleep(DELETE_WAIT_AFTER_SECONDS)
@@ -0,0 +1,325 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+#	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the API Gateway V2 WebSocket API resources
+"""
+
+import logging
+import time
+
+import boto3
+import pytest
+
+from acktest.k8s import resource as k8s
+from acktest.k8s import condition
+from acktest.resources import random_suffix_name
+
+from e2e import service_marker, load_apigatewayv2_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+import e2e.tests.helper as helper
+from e2e.tests.helper import ApiGatewayValidator
+
+CREATE_API_WAIT_AFTER_SECONDS = 60
+CREATE_WAIT_AFTER_SECONDS = 5
+DELETE_WAIT_AFTER_SECONDS = 20
+UPDATE_WAIT_AFTER_SECONDS = 20
+
+apigw_validator = ApiGatewayValidator(boto3.client('apigatewayv2'))
+test_resource_values = REPLACEMENT_VALUES.copy()
+
+
+@pytest.fixture(scope="module")
+def websocket_api_resource():
+    api_resource_name = random_suffix_name(test_resource_values['WEBSOCKET_API_NAME'], 30)
+    test_resource_values['WEBSOCKET_API_NAME'] = api_resource_name
+    api_ref, api_data = helper.websocket_api_ref_and_data(api_resource_name=api_resource_name,
+                                                          replacement_values=test_resource_values)
+    if k8s.get_resource_exists(api_ref):
+        raise Exception(f"expected {api_resource_name} to not exist. Did previous test cleanup?")
+    logging.debug(f"websocket api resource. name: {api_resource_name}, data: {api_data}")
+
+    k8s.create_custom_resource(api_ref, api_data)
+    time.sleep(CREATE_API_WAIT_AFTER_SECONDS)
+    assert k8s.wait_on_condition(api_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+    cr = k8s.get_resource(api_ref)
+    assert cr is not None
+
+    api_id = cr['status']['apiID']
+    test_resource_values['API_ID'] = api_id
+
+    yield api_ref, cr
+
+    k8s.delete_custom_resource(api_ref)
+
+
+@pytest.fixture(scope="module")
+def websocket_integration_resource(websocket_api_resource):
+    integration_resource_name = random_suffix_name(test_resource_values['WEBSOCKET_INTEGRATION_NAME'], 30)
+    test_resource_values['WEBSOCKET_INTEGRATION_NAME'] = integration_resource_name
+    integration_ref, integration_data = helper.integration_ref_and_data(
+        integration_resource_name=integration_resource_name,
+        replacement_values=test_resource_values,
+        file_name="websocket-integration",
+    )
+    if k8s.get_resource_exists(integration_ref):
+        raise Exception(f"expected {integration_resource_name} to not exist. Did previous test cleanup?")
+    logging.debug(f"websocket integration resource. name: {integration_resource_name}, data: {integration_data}")
+
+    k8s.create_custom_resource(integration_ref, integration_data)
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+    assert k8s.wait_on_condition(integration_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+    cr = k8s.get_resource(integration_ref)
+    assert cr is not None
+
+    yield integration_ref, cr
+
+    k8s.delete_custom_resource(integration_ref)
+
+
+@pytest.fixture(scope="module")
+def websocket_route_resource(websocket_integration_resource):
+    route_resource_name = random_suffix_name(test_resource_values['WEBSOCKET_ROUTE_NAME'], 30)
+    test_resource_values['WEBSOCKET_ROUTE_NAME'] = route_resource_name
+    route_ref, route_data = helper.route_ref_and_data(
+        route_resource_name=route_resource_name,
+        replacement_values=test_resource_values,
+        file_name="websocket-route",
+    )
+    if k8s.get_resource_exists(route_ref):
+        raise Exception(f"expected {route_resource_name} to not exist. Did previous test cleanup?")
+    logging.debug(f"websocket route resource. name: {route_resource_name}, data: {route_data}")
+
+    k8s.create_custom_resource(route_ref, route_data)
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+    assert k8s.wait_on_condition(route_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+    cr = k8s.get_resource(route_ref)
+    assert cr is not None
+
+    yield route_ref, cr
+
+    k8s.delete_custom_resource(route_ref)
+
+
+@service_marker
+@pytest.mark.canary
+class TestWebSocketApi:
+
+    def test_crud_model(self, websocket_api_resource):
+        api_ref, api_cr = websocket_api_resource
+        api_id = api_cr['status']['apiID']
+        test_data = REPLACEMENT_VALUES.copy()
+        model_name = random_suffix_name("ack-test-model", 25)
+        test_data['MODEL_NAME'] = model_name
+        test_data['API_ID'] = api_id
+        model_ref, model_data = helper.model_ref_and_data(model_resource_name=model_name,
+                                                          replacement_values=test_data)
+        logging.debug(f"websocket api model resource. name: {model_name}, data: {model_data}")
+
+        # test create
+        k8s.create_custom_resource(model_ref, model_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(model_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(model_ref)
+        assert cr is not None
+
+        model_id = cr['status']['modelID']
+
+        # Let's check that the model appears in Amazon API Gateway
+        apigw_validator.assert_model_is_present(api_id=api_id, model_id=model_id)
+
+        apigw_validator.assert_model_description(
+            api_id=api_id,
+            model_id=model_id,
+            expected_description=test_data['MODEL_DESCRIPTION']
+        )
+
+        # test update
+        updated_description = 'updated-' + test_data['MODEL_DESCRIPTION']
+        test_data['MODEL_DESCRIPTION'] = updated_description
+        updated_model_resource_data = load_apigatewayv2_resource(
+            "model",
+            additional_replacements=test_data,
+        )
+        logging.debug(f"updated websocket api model resource: {updated_model_resource_data}")
+
+        # Update the k8s resource
+        k8s.patch_custom_resource(model_ref, updated_model_resource_data)
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        condition.assert_synced(model_ref)
+        # Let's check that the model appears in Amazon API Gateway with updated description
+        apigw_validator.assert_model_description(
+            api_id=api_id,
+            model_id=model_id,
+            expected_description=updated_description
+        )
+
+        # test delete
+        k8s.delete_custom_resource(model_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(model_ref)
+        # Model should no longer appear in Amazon API Gateway
+        apigw_validator.assert_model_is_deleted(api_id=api_id, model_id=model_id)
+
+    def test_crud_integration_response(self, websocket_api_resource, websocket_integration_resource):
+        api_ref, api_cr = websocket_api_resource
+        integration_ref, integration_cr = websocket_integration_resource
+        api_id = api_cr['status']['apiID']
+        integration_id = integration_cr['status']['integrationID']
+        test_data = test_resource_values.copy()
+        integration_response_name = random_suffix_name("ack-test-integration-response", 40)
+        test_data['INTEGRATION_RESPONSE_NAME'] = integration_response_name
+        integration_response_ref, integration_response_data = helper.integration_response_ref_and_data(
+            integration_response_resource_name=integration_response_name,
+            replacement_values=test_data,
+        )
+        logging.debug(f"integration response resource. name: {integration_response_name}, "
+                      f"data: {integration_response_data}")
+
+        # test create
+        k8s.create_custom_resource(integration_response_ref, integration_response_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(integration_response_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(integration_response_ref)
+        assert cr is not None
+
+        integration_response_id = cr['status']['integrationResponseID']
+
+        # Let's check that the integration response appears in Amazon API Gateway
+        apigw_validator.assert_integration_response_is_present(
+            api_id=api_id,
+            integration_id=integration_id,
+            integration_response_id=integration_response_id,
+        )
+
+        # test update
+        updated_expression = 'updated' + test_data['TEMPLATE_SELECTION_EXPRESSION']
+        test_data['TEMPLATE_SELECTION_EXPRESSION'] = updated_expression
+        updated_integration_response_data = load_apigatewayv2_resource(
+            "integration-response",
+            additional_replacements=test_data,
+        )
+        logging.debug(f"updated integration response resource: {updated_integration_response_data}")
+
+        # Update the k8s resource
+        k8s.patch_custom_resource(integration_response_ref, updated_integration_response_data)
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        condition.assert_synced(integration_response_ref)
+        # Let's check that the integration response appears in Amazon API Gateway with updated expression
+        apigw_validator.assert_integration_response_template_selection_expression(
+            api_id=api_id,
+            integration_id=integration_id,
+            integration_response_id=integration_response_id,
+            expected_expression=updated_expression,
+        )
+
+        # test delete
+        k8s.delete_custom_resource(integration_response_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(integration_response_ref)
+        # Integration response should no longer appear in Amazon API Gateway
+        apigw_validator.assert_integration_response_is_deleted(
+            api_id=api_id,
+            integration_id=integration_id,
+            integration_response_id=integration_response_id,
+        )
+
+    def test_crud_route_response(self, websocket_api_resource, websocket_route_resource):
+        api_ref, api_cr = websocket_api_resource
+        route_ref, route_cr = websocket_route_resource
+        api_id = api_cr['status']['apiID']
+        route_id = route_cr['status']['routeID']
+        test_data = test_resource_values.copy()
+        model_name = random_suffix_name("ack-test-rr-model", 25)
+        test_data['MODEL_NAME'] = model_name
+        model_ref, model_data = helper.model_ref_and_data(model_resource_name=model_name,
+                                                          replacement_values=test_data)
+        route_response_name = random_suffix_name("ack-test-route-response", 40)
+        test_data['ROUTE_RESPONSE_NAME'] = route_response_name
+        route_response_ref, route_response_data = helper.route_response_ref_and_data(
+            route_response_resource_name=route_response_name,
+            replacement_values=test_data,
+        )
+        logging.debug(f"route response resource. name: {route_response_name}, data: {route_response_data}")
+
+        # the route response references this model by name
+        k8s.create_custom_resource(model_ref, model_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(model_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        # test create
+        k8s.create_custom_resource(route_response_ref, route_response_data)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(route_response_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(route_response_ref)
+        assert cr is not None
+
+        route_response_id = cr['status']['routeResponseID']
+        # the model resolved from responseModelRefs is kept out of the spec
+        assert 'responseModels' not in cr['spec']
+        assert cr['status']['resolvedResponseModels'] == {'json': test_data['MODEL_TITLE']}
+
+        # Let's check that the route response appears in Amazon API Gateway
+        apigw_validator.assert_route_response_is_present(
+            api_id=api_id,
+            route_id=route_id,
+            route_response_id=route_response_id,
+        )
+        apigw_validator.assert_route_response_models(
+            api_id=api_id,
+            route_id=route_id,
+            route_response_id=route_response_id,
+            expected_models={'json': test_data['MODEL_TITLE']},
+        )
+
+        # test update
+        updated_expression = 'updated' + test_data['MODEL_SELECTION_EXPRESSION']
+        test_data['MODEL_SELECTION_EXPRESSION'] = updated_expression
+        updated_route_response_data = load_apigatewayv2_resource(
+            "route-response",
+            additional_replacements=test_data,
+        )
+        logging.debug(f"updated route response resource: {updated_route_response_data}")
+
+        # Update the k8s resource
+        k8s.patch_custom_resource(route_response_ref, updated_route_response_data)
+        time.sleep(UPDATE_WAIT_AFTER_SECONDS)
+
+        condition.assert_synced(route_response_ref)
+        # Let's check that the route response appears in Amazon API Gateway with updated expression
+        apigw_validator.assert_route_response_model_selection_expression(
+            api_id=api_id,
+            route_id=route_id,
+            route_response_id=route_response_id,
+            expected_expression=updated_expression,
+        )
+
+        # test delete
+        k8s.delete_custom_resource(route_response_ref)
+        time.sleep(DELETE_WAIT_AFTER_SECONDS)
+        assert not k8s.get_resource_exists(route_response_ref)
+        # Route response should no longer appear in Amazon API Gateway
+        apigw_validator.assert_route_response_is_deleted(
+            api_id=api_id,
+            route_id=route_id,
+            route_response_id=route_response_id,
+        )
+
+        k8s.delete_custom_resource(model_ref)
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build integration
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+func TestDeletionOrdering(t *testing.T) {
+	c := newChain(newNamespace(t))
+	create(t, c.objects()...)
+	waitForSynced(t, c.objects()...)
+	cp.ResetCalls()
+
+	// The route still targets the integration, which uses the VPC link, so
+	// the controller keeps both until the route is gone without asking API
+	// Gateway to delete them.
+	remove(t, c.vpcLink, c.integration)
+	waitForCondition(t, c.integration, reference.ConditionTypeDeletionBlocked, corev1.ConditionTrue)
+	waitForCondition(t, c.vpcLink, reference.ConditionTypeDeletionBlocked, corev1.ConditionTrue)
+	if _, msg := condition(c.integration, reference.ConditionTypeDeletionBlocked); !strings.Contains(msg, "Route "+c.route.Namespace+"/pets") {
+		t.Errorf("DeletionBlocked message %q does not name the route", msg)
+	}
+	for _, op := range []string{"DeleteIntegration", "DeleteVpcLink"} {
+		if n := count(cp.Calls(), op); n != 0 {
+			t.Errorf("%s called %d times while dependents exist", op, n)
+		}
+	}
+
+	remove(t, c.route)
+	waitForDeleted(t, c.route, c.integration, c.vpcLink)
+	remove(t, c.api)
+	waitForDeleted(t, c.api)
+
+	if _, err := cp.GetVpcLink(ctx, &svcsdk.GetVpcLinkInput{VpcLinkId: c.vpcLink.Status.VPCLinkID}); err == nil {
+		t.Errorf("VPC link %s not deleted", *c.vpcLink.Status.VPCLinkID)
+	}
+	if _, err := cp.GetApi(ctx, &svcsdk.GetApiInput{ApiId: c.api.Status.APIID}); err == nil {
+		t.Errorf("API %s not deleted", *c.api.Status.APIID)
+	}
+}
+
+func TestDeletionCascade(t *testing.T) {
+	c := newChain(newNamespace(t))
+	create(t, c.objects()...)
+	waitForSynced(t, c.objects()...)
+
+	c.api.Annotations = map[string]string{reference.AnnotationDeletionCascade: "true"}
+	if err := kc.Update(ctx, c.api); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	remove(t, c.api)
+	waitForDeleted(t, c.route, c.integration, c.api)
+
+	if _, err := cp.GetApi(ctx, &svcsdk.GetApiInput{ApiId: c.api.Status.APIID}); err == nil {
+		t.Errorf("API %s not deleted", *c.api.Status.APIID)
+	}
+
+	// The VPC link does not refer to the API and is left alone.
+	remove(t, c.vpcLink)
+	waitForDeleted(t, c.vpcLink)
+}
@@ -0,0 +1,216 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+)
+
+const (
+	// timeout bounds the waits for the controller, which retries failed
+	// reconciliations with an exponential backoff.
+	timeout      = 2 * time.Minute
+	pollInterval = 250 * time.Millisecond
+
+	listenerARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/pets/0123456789abcdef/0123456789abcdef"
+)
+
+// chain is an HTTP API whose route targets an integration that connects to
+// a VPC link, all wired with references.
+type chain struct {
+	api         *svcapitypes.API
+	vpcLink     *svcapitypes.VPCLink
+	integration *svcapitypes.Integration
+	route       *svcapitypes.Route
+}
+
+func newChain(namespace string) *chain {
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: "pets"}
+	return &chain{
+		api: &svcapitypes.API{
+			ObjectMeta: meta,
+			Spec: svcapitypes.APISpec{
+				Name:         aws.String("pets"),
+				ProtocolType: aws.String("HTTP"),
+			},
+		},
+		vpcLink: &svcapitypes.VPCLink{
+			ObjectMeta: meta,
+			Spec: svcapitypes.VPCLinkSpec{
+				Name:      aws.String("pets"),
+				SubnetIDs: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+			},
+		},
+		integration: &svcapitypes.Integration{
+			ObjectMeta: meta,
+			Spec: svcapitypes.IntegrationSpec{
+				APIRef:               ref("pets"),
+				ConnectionRef:        ref("pets"),
+				ConnectionType:       aws.String("VPC_LINK"),
+				IntegrationMethod:    aws.String("ANY"),
+				IntegrationType:      aws.String("HTTP_PROXY"),
+				IntegrationURI:       aws.String(listenerARN),
+				PayloadFormatVersion: aws.String("1.0"),
+			},
+		},
+		route: &svcapitypes.Route{
+			ObjectMeta: meta,
+			Spec: svcapitypes.RouteSpec{
+				APIRef:    ref("pets"),
+				RouteKey:  aws.String("ANY /pets/{proxy+}"),
+				TargetRef: ref("pets"),
+			},
+		},
+	}
+}
+
+// objects returns the resources of the chain, dependents first.
+func (c *chain) objects() []client.Object {
+	return []client.Object{c.route, c.integration, c.vpcLink, c.api}
+}
+
+func ref(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	return &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+	}
+}
+
+// newNamespace creates a namespace for the resources of a test.
+func newNamespace(t *testing.T) string {
+	t.Helper()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "integration-"}}
+	if err := kc.Create(ctx, ns); err != nil {
+		t.Fatalf("cannot create namespace: %v", err)
+	}
+	return ns.Name
+}
+
+func create(t *testing.T, objs ...client.Object) {
+	t.Helper()
+	for _, obj := range objs {
+		if err := kc.Create(ctx, obj); err != nil {
+			t.Fatalf("cannot create %T %s: %v", obj, obj.GetName(), err)
+		}
+	}
+}
+
+func remove(t *testing.T, objs ...client.Object) {
+	t.Helper()
+	for _, obj := range objs {
+		if err := kc.Delete(ctx, obj); err != nil {
+			t.Fatalf("cannot delete %T %s: %v", obj, obj.GetName(), err)
+		}
+	}
+}
+
+// waitFor polls cond until it returns nil or the timeout expires.
+func waitFor(t *testing.T, what string, cond func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := cond()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s: %v", what, err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// waitForCondition waits until obj has the condition with the given status,
+// and refreshes obj.
+func waitForCondition(t *testing.T, obj client.Object, condType ackv1alpha1.ConditionType, want corev1.ConditionStatus) {
+	t.Helper()
+	what := fmt.Sprintf("%T %s to be %s=%s", obj, obj.GetName(), condType, want)
+	waitFor(t, what, func() error {
+		if err := kc.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		status, msg := condition(obj, condType)
+		if status != want {
+			return fmt.Errorf("%s=%q: %s", condType, status, msg)
+		}
+		return nil
+	})
+}
+
+// waitForSynced waits until all objs are synced, and refreshes them.
+func waitForSynced(t *testing.T, objs ...client.Object) {
+	t.Helper()
+	for _, obj := range objs {
+		waitForCondition(t, obj, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionTrue)
+	}
+}
+
+// waitForDeleted waits until all objs are gone from the API server.
+func waitForDeleted(t *testing.T, objs ...client.Object) {
+	t.Helper()
+	for _, obj := range objs {
+		waitFor(t, fmt.Sprintf("%T %s to be deleted", obj, obj.GetName()), func() error {
+			err := kc.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("finalizers %v", obj.GetFinalizers())
+		})
+	}
+}
+
+// condition returns the status and message of the condition of obj.
+func condition(obj client.Object, condType ackv1alpha1.ConditionType) (corev1.ConditionStatus, string) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err.Error()
+	}
+	conditions, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	for _, c := range conditions {
+		cond, _ := c.(map[string]interface{})
+		if cond["type"] == string(condType) {
+			msg, _ := cond["message"].(string)
+			return corev1.ConditionStatus(fmt.Sprint(cond["status"])), msg
+		}
+	}
+	return "", ""
+}
+
+// count returns the number of calls of op in calls.
+func count(calls []string, op string) int {
+	n := 0
+	for _, call := range calls {
+		if call == op {
+			n++
+		}
+	}
+	return n
+}
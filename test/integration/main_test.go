@@ -0,0 +1,158 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build integration
+
+// Package integration runs the controller binary against envtest and the
+// local API Gateway v2 stand-in of the controlplane package. The tests need
+// the envtest binaries, e.g. installed with
+//
+//	KUBEBUILDER_ASSETS=$(setup-envtest use -p path) go test -tags integration ./test/integration/...
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	svcapitypes "github.com/aws-controllers-k8s/apigatewayv2-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/sdkapi/fake"
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/test/controlplane"
+)
+
+var (
+	// cp is the API Gateway v2 control plane the controller talks to.
+	cp *fake.Client
+	// kc is a client of the envtest API server.
+	kc  client.Client
+	ctx = context.Background()
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Println("KUBEBUILDER_ASSETS is not set, skipping the integration tests")
+		os.Exit(0)
+	}
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	cp = fake.New()
+	srv := httptest.NewServer(controlplane.New(cp))
+	defer srv.Close()
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			filepath.Join("..", "..", "config", "crd", "common", "bases"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot start envtest: %v\n", err)
+		return 1
+	}
+	defer func() {
+		_ = env.Stop()
+	}()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = svcapitypes.AddToScheme(scheme)
+	_ = ackv1alpha1.AddToScheme(scheme)
+	kc, err = client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create client: %v\n", err)
+		return 1
+	}
+
+	stop, err := startController(env, srv.URL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot start controller: %v\n", err)
+		return 1
+	}
+	defer stop()
+	return m.Run()
+}
+
+// startController builds the controller binary and runs it against the
+// envtest API server, with the AWS endpoints overridden to endpointURL.
+// The controller logs go to stderr when ACK_INTEGRATION_LOGS is set.
+func startController(env *envtest.Environment, endpointURL string) (func(), error) {
+	dir, err := os.MkdirTemp("", "apigatewayv2-integration")
+	if err != nil {
+		return nil, err
+	}
+	bin := filepath.Join(dir, "controller")
+	build := exec.Command("go", "build", "-o", bin, filepath.Join("..", "..", "cmd", "controller"))
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		return nil, fmt.Errorf("cannot build controller: %v", err)
+	}
+
+	user, err := env.AddUser(envtest.User{Name: "controller", Groups: []string{"system:masters"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := user.KubeConfig()
+	if err != nil {
+		return nil, err
+	}
+	kubeconfigPath := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfigPath, kubeconfig, 0o600); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(bin,
+		"--aws-region", "us-west-2",
+		"--aws-endpoint-url", endpointURL,
+		"--aws-identity-endpoint-url", endpointURL,
+		"--allow-unsafe-aws-endpoint-urls",
+		"--enable-carm=false",
+		"--enable-leader-election=false",
+		"--metrics-addr", "0",
+		"--healthz-addr", "0",
+		"--reconcile-default-resync-seconds", "10",
+		"--log-level", "debug",
+	)
+	cmd.Env = append(os.Environ(),
+		"KUBECONFIG="+kubeconfigPath,
+		"AWS_ACCESS_KEY_ID=AKID",
+		"AWS_SECRET_ACCESS_KEY=SECRET",
+		"AWS_EC2_METADATA_DISABLED=true",
+	)
+	cmd.Stdout, cmd.Stderr = io.Discard, io.Discard
+	if os.Getenv("ACK_INTEGRATION_LOGS") != "" {
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return func() {
+		_ = cmd.Process.Signal(os.Interrupt)
+		_ = cmd.Wait()
+		_ = os.RemoveAll(dir)
+	}, nil
+}
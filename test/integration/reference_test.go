@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build integration
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+func TestReferenceChain(t *testing.T) {
+	c := newChain(newNamespace(t))
+	// The dependents are created first, so that their references can only
+	// be resolved once the resources they point at are synced.
+	create(t, c.objects()...)
+	waitForSynced(t, c.objects()...)
+
+	for _, obj := range []client.Object{c.route, c.integration} {
+		if status, msg := condition(obj, ackv1alpha1.ConditionTypeReferencesResolved); status != corev1.ConditionTrue {
+			t.Errorf("%T %s references resolved = %q: %s", obj, obj.GetName(), status, msg)
+		}
+	}
+
+	integration, err := cp.GetIntegration(ctx, &svcsdk.GetIntegrationInput{
+		ApiId:         c.api.Status.APIID,
+		IntegrationId: c.integration.Status.IntegrationID,
+	})
+	if err != nil {
+		t.Fatalf("GetIntegration() error = %v", err)
+	}
+	if got, want := aws.ToString(integration.ConnectionId), aws.ToString(c.vpcLink.Status.VPCLinkID); got != want {
+		t.Errorf("ConnectionId = %q, want the VPC link %q", got, want)
+	}
+	route, err := cp.GetRoute(ctx, &svcsdk.GetRouteInput{
+		ApiId:   c.api.Status.APIID,
+		RouteId: c.route.Status.RouteID,
+	})
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if got, want := aws.ToString(route.Target), "integrations/"+aws.ToString(c.integration.Status.IntegrationID); got != want {
+		t.Errorf("Target = %q, want %q", got, want)
+	}
+}
+
+func TestReferenceGrant(t *testing.T) {
+	platform := newChain(newNamespace(t))
+	create(t, platform.api)
+	waitForSynced(t, platform.api)
+
+	apps := newChain(newNamespace(t))
+	apps.route.Spec.APIRef.From.Namespace = aws.String(platform.api.Namespace)
+	apps.route.Spec.TargetRef = nil
+	create(t, apps.route)
+
+	// The API does not grant references from the namespace of the route.
+	waitForCondition(t, apps.route, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionTrue)
+	if _, msg := condition(apps.route, reference.ConditionTypeReferencesResolved); !strings.Contains(msg, "not granted") {
+		t.Errorf("ReferencesResolved message %q does not report the denied reference", msg)
+	}
+
+	platform.api.Annotations = map[string]string{
+		reference.AnnotationReferenceGrant: apps.route.Namespace + "/Route",
+	}
+	if err := kc.Update(ctx, platform.api); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	waitForSynced(t, apps.route)
+
+	route, err := cp.GetRoute(ctx, &svcsdk.GetRouteInput{
+		ApiId:   platform.api.Status.APIID,
+		RouteId: apps.route.Status.RouteID,
+	})
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v, want the route in the API of the platform namespace", err)
+	}
+	if got, want := aws.ToString(route.RouteKey), aws.ToString(apps.route.Spec.RouteKey); got != want {
+		t.Errorf("RouteKey = %q, want %q", got, want)
+	}
+
+	remove(t, apps.route)
+	waitForDeleted(t, apps.route)
+	remove(t, platform.api)
+	waitForDeleted(t, platform.api)
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build integration
+
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/apigatewayv2-controller/pkg/reference"
+)
+
+// backoff is long enough for the exponential backoff of a resource failing
+// to resolve its references to exceed half of it.
+const backoff = 20 * time.Second
+
+func TestRequeue_RecoverableError(t *testing.T) {
+	c := newChain(newNamespace(t))
+	c.vpcLink.Spec.Name = aws.String("retried")
+	cp.ResetCalls()
+	cp.Fail("CreateVpcLink", &svcsdktypes.ConflictException{Message: aws.String("try again")})
+
+	create(t, c.vpcLink)
+	waitForSynced(t, c.vpcLink)
+	if n := count(cp.Calls(), "CreateVpcLink"); n != 2 {
+		t.Errorf("CreateVpcLink called %d times, want 2", n)
+	}
+}
+
+func TestRequeue_UnresolvedReference(t *testing.T) {
+	c := newChain(newNamespace(t))
+	c.route.Spec.RouteKey = aws.String("GET /pets")
+	c.integration.Spec.ConnectionRef = nil
+	c.integration.Spec.ConnectionType = nil
+	c.integration.Spec.IntegrationURI = aws.String("https://example.com/pets")
+	create(t, c.api, c.route)
+	waitForSynced(t, c.api)
+
+	// The route waits for the integration it targets, which its
+	// ReferencesResolved condition reports as missing.
+	waitForCondition(t, c.route, reference.ConditionTypeReferencesResolved, corev1.ConditionFalse)
+	_, msg := condition(c.route, reference.ConditionTypeReferencesResolved)
+	if want := "spec.targetRef: Integration " + c.route.Namespace + "/pets missing"; !strings.Contains(msg, want) {
+		t.Errorf("ReferencesResolved message %q does not contain %q", msg, want)
+	}
+	routes, err := cp.GetRoutes(ctx, &svcsdk.GetRoutesInput{ApiId: c.api.Status.APIID})
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if len(routes.Items) != 0 {
+		t.Fatalf("GetRoutes() = %d routes, want none before the integration exists", len(routes.Items))
+	}
+
+	create(t, c.integration)
+	waitForSynced(t, c.integration, c.route)
+	route, err := cp.GetRoute(ctx, &svcsdk.GetRouteInput{ApiId: c.api.Status.APIID, RouteId: c.route.Status.RouteID})
+	if err != nil {
+		t.Fatalf("GetRoute() error = %v", err)
+	}
+	if got, want := aws.ToString(route.Target), "integrations/"+aws.ToString(c.integration.Status.IntegrationID); got != want {
+		t.Errorf("Target = %q, want %q", got, want)
+	}
+}
+
+func TestRequeue_ReferenceTargetSynced(t *testing.T) {
+	c := newChain(newNamespace(t))
+	c.route.Spec.RouteKey = aws.String("GET /pets")
+	c.integration.Spec.ConnectionRef = nil
+	c.integration.Spec.ConnectionType = nil
+	c.integration.Spec.IntegrationURI = aws.String("https://example.com/pets")
+	create(t, c.api, c.route)
+	waitForSynced(t, c.api)
+	waitForCondition(t, c.route, reference.ConditionTypeReferencesResolved, corev1.ConditionFalse)
+
+	// Let the rate limiter back off the retries of the route well beyond
+	// the delay allowed below, so that only the watch on the integration
+	// can explain a prompt sync.
+	time.Sleep(backoff)
+
+	create(t, c.integration)
+	waitForSynced(t, c.integration)
+	synced := time.Now()
+	waitForSynced(t, c.route)
+	if elapsed := time.Since(synced); elapsed > backoff/2 {
+		t.Errorf("route synced %s after the integration, want it requeued by the watch", elapsed)
+	}
+}